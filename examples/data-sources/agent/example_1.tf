@@ -0,0 +1,13 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_agent" "example_name" {
+  name = "agent-eu-1"
+}
+
+output "status" {
+  value = "${data.rmon_agent.example_name.status} (${data.rmon_agent.example_name.version})"
+}
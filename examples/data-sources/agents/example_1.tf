@@ -0,0 +1,18 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_agents" "healthy" {
+  region_id = 1
+  status    = "online"
+}
+
+resource "rmon_check_ping" "example" {
+  name     = "Ping check"
+  enabled  = true
+  place    = "agent"
+  entities = [for agent in data.rmon_agents.healthy.agents : agent.id]
+  ip       = "example.com"
+}
@@ -0,0 +1,35 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_channel" "example_id" {
+  receiver = "telegram"
+  id       = "3"
+}
+
+output "view" {
+  value = data.rmon_channel.example_id
+}
+
+// ------------------------------------
+
+data "rmon_channel" "example_name" {
+  receiver = "slack"
+  channel  = "alerts"
+  group_id = 1
+}
+
+resource "rmon_check_ping" "example" {
+  name     = "Ping check"
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  ip       = "example.com"
+
+  notification {
+    receiver   = "slack"
+    channel_id = data.rmon_channel.example_name.id
+  }
+}
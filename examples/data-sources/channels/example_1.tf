@@ -0,0 +1,22 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_channels" "all" {}
+
+output "all" {
+  value = data.rmon_channels.all.channels
+}
+
+// ------------------------------------
+
+data "rmon_channels" "telegram" {
+  receiver = "telegram"
+  group_id = 1
+}
+
+output "telegram" {
+  value = data.rmon_channels.telegram.channels
+}
@@ -0,0 +1,29 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+resource "rmon_check_http" "api" {
+  name     = "api-health"
+  enabled  = true
+  place    = "region"
+  entities = [2]
+  url      = "https://api.example.com/health"
+}
+
+data "rmon_check_status" "api" {
+  type     = "http"
+  check_id = rmon_check_http.api.id
+
+  lifecycle {
+    postcondition {
+      condition     = self.status == "up"
+      error_message = "The API is ${self.status}: ${self.error}"
+    }
+  }
+}
+
+output "api_response_time_ms" {
+  value = data.rmon_check_status.api.response_time
+}
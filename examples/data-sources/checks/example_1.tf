@@ -0,0 +1,24 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_checks" "all" {}
+
+output "targets" {
+  value = { for check in data.rmon_checks.all.checks : "${check.type}/${check.id}" => check.target }
+}
+
+// ------------------------------------
+
+data "rmon_checks" "api_without_channels" {
+  type        = "http"
+  check_group = "api"
+  enabled     = true
+  name_regex  = "^api-"
+}
+
+output "silent_checks" {
+  value = [for check in data.rmon_checks.api_without_channels.checks : check.name if length(check.channels) == 0]
+}
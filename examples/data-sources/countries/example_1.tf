@@ -0,0 +1,15 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_countries" "all" {}
+
+resource "rmon_check_ping" "example" {
+  name     = "Ping check"
+  enabled  = true
+  place    = "country"
+  entities = [for country in data.rmon_countries.all.countries : country.id]
+  ip       = "example.com"
+}
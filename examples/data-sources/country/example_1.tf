@@ -0,0 +1,18 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_country" "example" {
+  name = "Germany"
+}
+
+resource "rmon_region" "example" {
+  name        = "eu-central"
+  description = "Frankfurt"
+  enabled     = true
+  shared      = true
+  country_id  = data.rmon_country.example.id
+  group_id    = 1
+}
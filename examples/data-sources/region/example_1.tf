@@ -0,0 +1,17 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_region" "example" {
+  name = "eu-central"
+}
+
+resource "rmon_check_ping" "example" {
+  name     = "Ping check"
+  enabled  = true
+  place    = "region"
+  entities = [data.rmon_region.example.id]
+  ip       = "example.com"
+}
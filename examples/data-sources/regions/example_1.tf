@@ -0,0 +1,14 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_regions" "shared" {
+  group_id = 1
+  shared   = true
+}
+
+output "region_names" {
+  value = [for region in data.rmon_regions.shared.regions : region.name]
+}
@@ -0,0 +1,28 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_server" "example_id" {
+  id = "4"
+}
+
+output "view" {
+  value = data.rmon_server.example_id
+}
+
+// ------------------------------------
+
+data "rmon_server" "example_hostname" {
+  hostname = "redis01"
+}
+
+resource "rmon_agent" "example" {
+  name        = "agent-redis01"
+  server_id   = data.rmon_server.example_hostname.id
+  port        = 5101
+  description = "Agent on redis01"
+  enabled     = true
+  shared      = false
+}
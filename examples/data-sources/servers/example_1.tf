@@ -0,0 +1,15 @@
+provider "rmon" {
+  base_url = "https://..."
+  login    = "test"
+  password = "testpass"
+}
+
+data "rmon_servers" "redis" {
+  group_id       = 1
+  enabled        = true
+  hostname_regex = "^redis"
+}
+
+output "redis_ips" {
+  value = [for server in data.rmon_servers.redis.servers : server.ip]
+}
@@ -0,0 +1,9 @@
+provider "rmon" {
+  base_url  = "https://your_rmon"
+  api_token = var.rmon_api_token
+}
+
+variable "rmon_api_token" {
+  type      = string
+  sensitive = true
+}
@@ -5,8 +5,9 @@ provider "rmon" {
 }
 
 resource "rmon_channel" "example" {
-  receiver = "pd"
-  channel  = "test_my_channel"
-  group_id = 1
-  token    = "some_token"
+  receiver        = "pd"
+  channel         = "test_my_channel"
+  group_id        = 1
+  token           = "some_token"
+  verify_on_apply = true
 }
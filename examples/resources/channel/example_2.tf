@@ -1,4 +1,4 @@
 import {
   to = rmon_channel.example
-  id = "1"
+  id = "telegram/1"
 }
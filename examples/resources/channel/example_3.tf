@@ -0,0 +1,12 @@
+resource "rmon_channel" "webhook" {
+  receiver         = "webhook"
+  channel          = "on-call"
+  group_id         = 1
+  webhook_url      = "https://hooks.example.com/rmon"
+  hmac_secret      = "webhook-signing-secret"
+  payload_template = jsonencode({ text = "{{ .Message }}" })
+
+  headers = {
+    "X-Team" = "sre"
+  }
+}
@@ -1 +1 @@
-% terraform import rmon_channel.example 1
\ No newline at end of file
+% terraform import rmon_channel.example telegram/1
\ No newline at end of file
@@ -18,4 +18,15 @@ resource "rmon_check_http" "example" {
 EOF
   http_method           = "get"
   accepted_status_codes = 200
+
+  notification {
+    channel_id = 1
+    receiver   = "telegram"
+  }
+
+  notification {
+    channel_id = 2
+    receiver   = "slack"
+    severity   = "critical"
+  }
 }
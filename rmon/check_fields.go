@@ -1,5 +1,14 @@
 package rmon
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
 const (
 	CheckGroupIdFiled        = "check_group"
 	PlaceField               = "place"
@@ -26,3 +35,158 @@ const (
 	RedirectsField           = "redirects"
 	RunbookField             = "runbook"
 )
+
+// checkBaseSchema returns the attributes shared by every check type; label
+// names the check type in descriptions.
+func checkBaseSchema(label string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		NameField: {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: fmt.Sprintf("Name of the Check %s.", label),
+		},
+		DescriptionField: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Description of the Check %s.", label),
+		},
+		EnabledField: {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: fmt.Sprintf("Enabled state of the Check %s.", label),
+		},
+		CheckGroupIdFiled: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Name of the check group for group %s checks.", label),
+		},
+		PlaceField: {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Where the check must be created: `all`, `country`, `region` or `agent`.",
+			ValidateFunc: validation.StringInSlice([]string{
+				"all",
+				"country",
+				"region",
+				"agent",
+			}, false),
+		},
+		EntitiesField: {
+			Type:        schema.TypeList,
+			Required:    true,
+			Description: "List of entities where check must be created.",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		IntervalField: {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Interval in seconds between checks.",
+		},
+		TimeoutField: {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Answer timeout in seconds.",
+		},
+		TelegramField: {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "Telegram channel ID for alerts.",
+			Deprecated:    "Use a notification block instead.",
+			ConflictsWith: []string{NotificationField},
+		},
+		SlackField: {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "Slack channel ID for alerts.",
+			Deprecated:    "Use a notification block instead.",
+			ConflictsWith: []string{NotificationField},
+		},
+		MMField: {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "Mattermost channel ID for alerts.",
+			Deprecated:    "Use a notification block instead.",
+			ConflictsWith: []string{NotificationField},
+		},
+		PDField: {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			Description:   "PagerDuty channel ID for alerts.",
+			Deprecated:    "Use a notification block instead.",
+			ConflictsWith: []string{NotificationField},
+		},
+		NotificationField: notificationSchema(),
+		RetriesField: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Number of retries before check is marked down.",
+			ValidateFunc: validation.IntAtLeast(0),
+			Default:      3,
+		},
+		RunbookField: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Runbook URL for alerts.",
+		},
+	}
+}
+
+// expandCheckBase builds the attributes shared by every check type.
+func expandCheckBase(d *schema.ResourceData, config *Config) rmonapi.CheckBase {
+	rawEntities := d.Get(EntitiesField).([]interface{})
+	entities := make([]int, 0, len(rawEntities))
+	for _, entity := range rawEntities {
+		entities = append(entities, entity.(int))
+	}
+
+	check := rmonapi.CheckBase{
+		Name:              strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
+		Description:       strings.ReplaceAll(d.Get(DescriptionField).(string), "'", ""),
+		Enabled:           rmonapi.Bool(d.Get(EnabledField).(bool)),
+		CheckGroup:        d.Get(CheckGroupIdFiled).(string),
+		Place:             d.Get(PlaceField).(string),
+		Entities:          entities,
+		Interval:          d.Get(IntervalField).(int),
+		Timeout:           d.Get(TimeoutField).(int),
+		TelegramChannelID: d.Get(TelegramField).(int),
+		SlackChannelID:    d.Get(SlackField).(int),
+		MMChannelID:       d.Get(MMField).(int),
+		PDChannelID:       d.Get(PDField).(int),
+		EmailChannelID:    config.gatedChannelID(EmailChannelIDField),
+		WebhookChannelID:  config.gatedChannelID(WebhookChannelIDField),
+		TeamsChannelID:    config.gatedChannelID(TeamsChannelIDField),
+		DiscordChannelID:  config.gatedChannelID(DiscordChannelIDField),
+		OpsgenieChannelID: config.gatedChannelID(OpsgenieChannelIDField),
+		Retries:           config.gatedInt(d, RetriesField),
+		Runbook:           config.gatedString(d, RunbookField),
+	}
+	expandNotifications(d, config, &check)
+
+	return check
+}
+
+func flattenCheckBase(d *schema.ResourceData, config *Config, check *rmonapi.CheckBase) {
+	entities := check.Entities
+	if check.Place == "all" {
+		entities = []int{}
+	}
+
+	d.Set(DescriptionField, strings.ReplaceAll(check.Description, "'", ""))
+	d.Set(EnabledField, bool(check.Enabled))
+	d.Set(NameField, strings.ReplaceAll(check.Name, "'", ""))
+	d.Set(CheckGroupIdFiled, check.CheckGroup)
+	d.Set(PlaceField, check.Place)
+	d.Set(EntitiesField, entities)
+	d.Set(IntervalField, check.Interval)
+	d.Set(TimeoutField, check.Timeout)
+	flattenNotifications(d, check)
+
+	if config.supportsField(RetriesField) && check.Retries != nil {
+		d.Set(RetriesField, *check.Retries)
+	}
+	if config.supportsField(RunbookField) && check.Runbook != nil {
+		d.Set(RunbookField, *check.Runbook)
+	}
+}
@@ -0,0 +1,322 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
+const (
+	NotificationField = "notification"
+	ChannelIDField    = "channel_id"
+	SeverityField     = "severity"
+	TriggerField      = "trigger"
+
+	// NotificationListField is the RMON check field carrying the severity and
+	// trigger filters of the notification blocks.
+	NotificationListField = "notifications"
+
+	// The RMON check fields of the receivers without a legacy attribute.
+	EmailChannelIDField    = "email_channel_id"
+	WebhookChannelIDField  = "webhook_channel_id"
+	TeamsChannelIDField    = "teams_channel_id"
+	DiscordChannelIDField  = "discord_channel_id"
+	OpsgenieChannelIDField = "opsgenie_channel_id"
+)
+
+// notificationReceiver describes how a check routes alerts to one receiver
+// type. Supporting a new RMON receiver only needs an entry in
+// notificationReceivers.
+type notificationReceiver struct {
+	name string
+	// field is the RMON check field holding the channel ID, and the key of
+	// the receiver in checkFieldMinVersions.
+	field string
+	// legacyField is the deprecated top-level attribute for the receiver, if
+	// it has one.
+	legacyField string
+	// channelID returns the check field RMON routes this receiver by. It is
+	// nil for fields the server does not know.
+	channelID func(check *rmonapi.CheckBase) *int
+}
+
+// id returns the channel ID the check routes the receiver to, or 0.
+func (r notificationReceiver) id(check *rmonapi.CheckBase) int {
+	if id := r.channelID(check); id != nil {
+		return *id
+	}
+	return 0
+}
+
+var notificationReceivers = []notificationReceiver{
+	{
+		name:        ReceiverTypeTelegram,
+		field:       TelegramField,
+		legacyField: TelegramField,
+		channelID:   func(check *rmonapi.CheckBase) *int { return &check.TelegramChannelID },
+	},
+	{
+		name:        ReceiverTypeSlack,
+		field:       SlackField,
+		legacyField: SlackField,
+		channelID:   func(check *rmonapi.CheckBase) *int { return &check.SlackChannelID },
+	},
+	{
+		name:        ReceiverTypeMattermost,
+		field:       MMField,
+		legacyField: MMField,
+		channelID:   func(check *rmonapi.CheckBase) *int { return &check.MMChannelID },
+	},
+	{
+		name:        ReceiverTypePagerDuty,
+		field:       PDField,
+		legacyField: PDField,
+		channelID:   func(check *rmonapi.CheckBase) *int { return &check.PDChannelID },
+	},
+	{
+		name:      ReceiverTypeEmail,
+		field:     EmailChannelIDField,
+		channelID: func(check *rmonapi.CheckBase) *int { return check.EmailChannelID },
+	},
+	{
+		name:      ReceiverTypeWebhook,
+		field:     WebhookChannelIDField,
+		channelID: func(check *rmonapi.CheckBase) *int { return check.WebhookChannelID },
+	},
+	{
+		name:      ReceiverTypeTeams,
+		field:     TeamsChannelIDField,
+		channelID: func(check *rmonapi.CheckBase) *int { return check.TeamsChannelID },
+	},
+	{
+		name:      ReceiverTypeDiscord,
+		field:     DiscordChannelIDField,
+		channelID: func(check *rmonapi.CheckBase) *int { return check.DiscordChannelID },
+	},
+	{
+		name:      ReceiverTypeOpsgenie,
+		field:     OpsgenieChannelIDField,
+		channelID: func(check *rmonapi.CheckBase) *int { return check.OpsgenieChannelID },
+	},
+}
+
+var (
+	notificationSeverities = []string{"info", "warning", "critical"}
+	notificationTriggers   = []string{"down", "up", "any"}
+)
+
+func findNotificationReceiver(name string) (notificationReceiver, bool) {
+	for _, receiver := range notificationReceivers {
+		if receiver.name == name {
+			return receiver, true
+		}
+	}
+	return notificationReceiver{}, false
+}
+
+func notificationReceiverNames() []string {
+	names := make([]string, 0, len(notificationReceivers))
+	for _, receiver := range notificationReceivers {
+		names = append(names, receiver.name)
+	}
+	return names
+}
+
+func legacyNotificationFields() []string {
+	var fields []string
+	for _, receiver := range notificationReceivers {
+		if receiver.legacyField != "" {
+			fields = append(fields, receiver.legacyField)
+		}
+	}
+	return fields
+}
+
+func notificationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		Description:   "Channel the alerts of the check are sent to. Can be repeated, once per receiver.",
+		ConflictsWith: legacyNotificationFields(),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				ChannelIDField: {
+					Type:        schema.TypeInt,
+					Required:    true,
+					Description: "ID of the channel.",
+				},
+				ReceiverField: {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  fmt.Sprintf("The type of the receiver of the channel. Must match the `receiver` of the channel. One of %s. Receivers other than `telegram`, `slack`, `mm` and `pd` require RMON >= 1.2.0.", quoteList(notificationReceiverNames())),
+					ValidateFunc: validation.StringInSlice(notificationReceiverNames(), false),
+				},
+				SeverityField: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  fmt.Sprintf("Only send alerts of this severity. One of %s. Requires RMON >= 1.2.0.", quoteList(notificationSeverities)),
+					ValidateFunc: validation.StringInSlice(notificationSeverities, false),
+				},
+				TriggerField: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  fmt.Sprintf("Only send alerts on this state change. One of %s. Requires RMON >= 1.2.0.", quoteList(notificationTriggers)),
+					ValidateFunc: validation.StringInSlice(notificationTriggers, false),
+				},
+			},
+		},
+	}
+}
+
+func expandNotifications(d *schema.ResourceData, config *Config, check *rmonapi.CheckBase) {
+	// An empty list rather than null, so that removed blocks are cleared.
+	notifications := []rmonapi.Notification{}
+	for _, raw := range d.Get(NotificationField).([]interface{}) {
+		item := raw.(map[string]interface{})
+		notification := rmonapi.Notification{
+			ChannelID: item[ChannelIDField].(int),
+			Receiver:  item[ReceiverField].(string),
+			Severity:  item[SeverityField].(string),
+			Trigger:   item[TriggerField].(string),
+		}
+		notifications = append(notifications, notification)
+
+		// RMON routes by a single channel per receiver, which
+		// validateNotificationReceivers enforces at plan time.
+		if receiver, ok := findNotificationReceiver(notification.Receiver); ok && receiver.channelID(check) != nil {
+			*receiver.channelID(check) = notification.ChannelID
+		}
+	}
+
+	// Older servers only know the channel IDs, which is enough as long as
+	// validateNotificationReceivers keeps the filters away from them.
+	if config.supportsField(NotificationListField) {
+		check.Notifications = &notifications
+	}
+}
+
+func flattenNotifications(d *schema.ResourceData, check *rmonapi.CheckBase) {
+	notifications := check.NotificationList()
+
+	// Servers older than 1.2.0 do not send the notification list, only the
+	// channel IDs the blocks in state are matched against.
+	if len(notifications) == 0 && len(d.Get(NotificationField).([]interface{})) > 0 {
+		notifications = mergeNotificationChannelIDs(d, check)
+	}
+
+	if len(notifications) == 0 {
+		d.Set(NotificationField, nil)
+		for _, receiver := range notificationReceivers {
+			if receiver.legacyField != "" {
+				d.Set(receiver.legacyField, receiver.id(check))
+			}
+		}
+		return
+	}
+
+	items := make([]map[string]interface{}, 0, len(notifications))
+	for _, notification := range notifications {
+		items = append(items, map[string]interface{}{
+			ChannelIDField: notification.ChannelID,
+			ReceiverField:  notification.Receiver,
+			SeverityField:  notification.Severity,
+			TriggerField:   notification.Trigger,
+		})
+	}
+	d.Set(NotificationField, items)
+
+	// The channel IDs mirror the notification blocks, keep the deprecated
+	// attributes empty so they do not show up as drift.
+	for _, field := range legacyNotificationFields() {
+		d.Set(field, 0)
+	}
+}
+
+// mergeNotificationChannelIDs rebuilds the notification blocks from the
+// per-receiver channel IDs. The blocks in state keep their order and filters,
+// which the channel IDs cannot carry, and receivers without a block are
+// appended.
+func mergeNotificationChannelIDs(d *schema.ResourceData, check *rmonapi.CheckBase) []rmonapi.Notification {
+	var notifications []rmonapi.Notification
+	seen := make(map[string]bool)
+	for _, raw := range d.Get(NotificationField).([]interface{}) {
+		item := raw.(map[string]interface{})
+		receiver, ok := findNotificationReceiver(item[ReceiverField].(string))
+		if !ok || seen[receiver.name] {
+			continue
+		}
+		seen[receiver.name] = true
+
+		id := receiver.id(check)
+		if id == 0 {
+			continue
+		}
+		notifications = append(notifications, rmonapi.Notification{
+			ChannelID: id,
+			Receiver:  receiver.name,
+			Severity:  item[SeverityField].(string),
+			Trigger:   item[TriggerField].(string),
+		})
+	}
+
+	for _, receiver := range notificationReceivers {
+		if id := receiver.id(check); id != 0 && !seen[receiver.name] {
+			notifications = append(notifications, rmonapi.Notification{ChannelID: id, Receiver: receiver.name})
+		}
+	}
+	return notifications
+}
+
+// validateNotificationReceivers rejects several notification blocks for the
+// same receiver, as RMON only keeps one channel per receiver, and receivers or
+// filters the connected RMON is too old for.
+func validateNotificationReceivers(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config, _ := m.(*Config)
+	seen := make(map[string]bool)
+	for _, raw := range d.Get(NotificationField).([]interface{}) {
+		item, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := item[ReceiverField].(string)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return fmt.Errorf("only one `%s` block per receiver is supported, %q is used more than once", NotificationField, name)
+		}
+		seen[name] = true
+
+		if receiver, ok := findNotificationReceiver(name); ok {
+			if err := config.checkMinVersion(receiver.field); err != nil {
+				return fmt.Errorf("notification receiver %q: %w", name, err)
+			}
+		}
+		for _, filter := range []string{SeverityField, TriggerField} {
+			if value, _ := item[filter].(string); value == "" {
+				continue
+			}
+			if err := config.checkMinVersion(filter); err != nil {
+				return fmt.Errorf("notification receiver %q: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNotificationChannels makes sure every referenced channel exists
+// with the declared receiver, as RMON silently drops alerts otherwise.
+func validateNotificationChannels(ctx context.Context, client *rmonapi.Client, check *rmonapi.CheckBase) error {
+	for _, notification := range check.NotificationList() {
+		if _, err := client.Channels.Get(ctx, notification.Receiver, notification.ChannelID); err != nil {
+			if rmonapi.IsNotFound(err) {
+				return fmt.Errorf("channel %d is not a %s channel", notification.ChannelID, notification.Receiver)
+			}
+			return err
+		}
+	}
+	return nil
+}
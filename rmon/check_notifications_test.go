@@ -0,0 +1,327 @@
+package rmon
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccCheckNotification(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/tcp", "rmon_check_tcp"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.telegram.id
+    receiver   = "telegram"
+  }
+
+  notification {
+    channel_id = rmon_channel.slack.id
+    receiver   = "slack"
+    severity   = "critical"
+    trigger    = "down"
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreID("rmon_check_tcp.test", &id),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "telegram_channel_id", 1),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "slack_channel_id", 1),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "2"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.1.receiver", "slack"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.1.severity", "critical"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.1.trigger", "down"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "telegram_channel_id", "0"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_tcp.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.slack.id
+    receiver   = "slack"
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_tcp.test", &id),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "telegram_channel_id", 0),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "1"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.0.severity", ""),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  telegram_channel_id = rmon_channel.telegram.id
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "telegram_channel_id", 1),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "slack_channel_id", 0),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "0"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "telegram_channel_id", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCheckNotification_email(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.email.id
+    receiver   = "email"
+  }
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/tcp", "rmon_check_tcp"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, config),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "email_channel_id", 1),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "1"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.0.receiver", "email"),
+				),
+			},
+			{
+				// Servers without the notification list only report the
+				// channel ID, which must be enough to avoid a diff.
+				Config: testAccConfig(srv, config),
+				Check:  testAccUpdateOutOfBand(srv, "rmon/check/tcp", "rmon_check_tcp.test", rmontest.Object{"notifications": nil}),
+			},
+			{
+				Config:             testAccConfig(srv, config),
+				Check:              testAccUpdateOutOfBand(srv, "rmon/check/tcp", "rmon_check_tcp.test", rmontest.Object{"email_channel_id": 0}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccCheckNotification_withoutNotificationList(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.slack.id
+    receiver   = "slack"
+    severity   = "critical"
+  }
+
+  notification {
+    channel_id = rmon_channel.telegram.id
+    receiver   = "telegram"
+    trigger    = "down"
+  }
+`)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/tcp", "rmon_check_tcp"),
+		Steps: []resource.TestStep{
+			{
+				// The blocks are out of receiver order and carry filters,
+				// neither of which the channel IDs can express.
+				Config: testAccConfig(srv, config),
+				Check: resource.ComposeTestCheckFunc(
+					testAccUpdateOutOfBand(srv, "rmon/check/tcp", "rmon_check_tcp.test", rmontest.Object{"notifications": nil}),
+				),
+			},
+			{
+				Config: testAccConfig(srv, config),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "2"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.0.receiver", "slack"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.0.severity", "critical"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.1.receiver", "telegram"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.1.trigger", "down"),
+					testAccUpdateOutOfBand(srv, "rmon/check/tcp", "rmon_check_tcp.test", rmontest.Object{"notifications": nil, "slack_channel_id": 0}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccCheckNotification_versionGate(t *testing.T) {
+	srv := testAccServer(t)
+	srv.SetVersion("1.1.0")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/tcp", "rmon_check_tcp"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.telegram.id
+    receiver   = "telegram"
+    severity   = "critical"
+  }
+`)),
+				ExpectError: regexp.MustCompile(`receiver "telegram".+severity.+requires RMON\s+>=\s+1\.2\.0`),
+			},
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.email.id
+    receiver   = "email"
+  }
+`)),
+				ExpectError: regexp.MustCompile(`receiver "email".+email_channel_id.+requires RMON\s+>=\s+1\.2\.0`),
+			},
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = 1
+    receiver   = "opsgenie"
+  }
+`)),
+				ExpectError: regexp.MustCompile(`receiver "opsgenie".+opsgenie_channel_id.+requires RMON\s+>=\s+1\.2\.0`),
+			},
+			{
+				// Blocks of the legacy receivers map onto the channel IDs,
+				// and the notification list is not sent to a server that
+				// would reject it.
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.slack.id
+    receiver   = "slack"
+  }
+
+  notification {
+    channel_id = rmon_channel.telegram.id
+    receiver   = "telegram"
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "telegram_channel_id", 1),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "slack_channel_id", 1),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "notifications", nil),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "email_channel_id", nil),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "2"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.0.receiver", "slack"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.telegram.id
+    receiver   = "telegram"
+  }
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "telegram_channel_id", 1),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "slack_channel_id", 0),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "1"),
+				),
+			},
+			{
+				// The legacy channel IDs still work.
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  telegram_channel_id = rmon_channel.telegram.id
+`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "telegram_channel_id", 1),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "notifications", nil),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "email_channel_id", nil),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "webhook_channel_id", nil),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "teams_channel_id", nil),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "discord_channel_id", nil),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "opsgenie_channel_id", nil),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "notification.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCheckNotification_receiverMismatch(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = rmon_channel.telegram.id + 1
+    receiver   = "slack"
+  }
+`)),
+				ExpectError: regexp.MustCompile(`channel 2 is not a slack channel`),
+			},
+		},
+	})
+}
+
+func TestAccCheckNotification_duplicateReceiver(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccCheckNotificationConfig(`
+  notification {
+    channel_id = 1
+    receiver   = "telegram"
+  }
+
+  notification {
+    channel_id = 2
+    receiver   = "telegram"
+    severity   = "critical"
+  }
+`)),
+				ExpectError: regexp.MustCompile(`only one .notification. block per receiver is supported, "telegram" is used`),
+			},
+		},
+	})
+}
+
+func testAccCheckNotificationConfig(notifications string) string {
+	return fmt.Sprintf(`
+resource "rmon_channel" "telegram" {
+  receiver = "telegram"
+  channel  = "alerts"
+  group_id = 1
+  token    = "bot-token"
+}
+
+resource "rmon_channel" "slack" {
+  receiver = "slack"
+  channel  = "alerts"
+  group_id = 1
+  token    = "bot-token"
+}
+
+resource "rmon_channel" "email" {
+  receiver = "email"
+  channel  = "oncall@example.com"
+  group_id = 1
+  token    = "smtp-token"
+}
+
+resource "rmon_check_tcp" "test" {
+  name     = "tcp check"
+  place    = "agent"
+  entities = [1]
+  ip       = "10.0.0.1"
+  port     = 443
+%s}
+`, notifications)
+}
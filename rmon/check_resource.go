@@ -0,0 +1,155 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
+)
+
+// checkSpec describes a single check type. Its resource method provides the
+// common attributes, CRUD and import, so a spec only declares what is
+// specific to its type.
+type checkSpec[T any, PT interface {
+	*T
+	rmonapi.Check
+}] struct {
+	// label names the check type in descriptions, e.g. "HTTP(s)".
+	label   string
+	service func(checks *rmonapi.CheckServices) *rmonapi.CheckService[T]
+	schema  map[string]*schema.Schema
+	// expand and flatten map the type-specific attributes only.
+	expand  func(d *schema.ResourceData, config *Config, check PT) error
+	flatten func(d *schema.ResourceData, config *Config, check PT)
+	// reconfigureOn lists the attributes whose change requires RMON to
+	// redeploy the check on its agents.
+	reconfigureOn []string
+}
+
+func (s checkSpec[T, PT]) resource() *schema.Resource {
+	checkSchema := checkBaseSchema(s.label)
+	for field, fieldSchema := range s.schema {
+		checkSchema[field] = fieldSchema
+	}
+
+	return &schema.Resource{
+		CreateContext: s.create,
+		ReadContext:   s.read,
+		UpdateContext: s.update,
+		DeleteContext: s.delete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Description: fmt.Sprintf("This resource manages %s check in RMON.", s.label),
+
+		CustomizeDiff: customdiff.All(checkFieldVersionsDiff, validateNotificationReceivers),
+
+		Schema: checkSchema,
+	}
+}
+
+func (s checkSpec[T, PT]) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+
+	check, err := s.expandCheck(d, config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := validateNotificationChannels(ctx, config.Client, check.Base()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	id, err := s.service(config.Client.Checks).Create(ctx, check)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(id))
+	return s.read(ctx, d, m)
+}
+
+func (s checkSpec[T, PT]) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	check, err := s.service(config.Client.Checks).Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	flattenCheckBase(d, config, PT(check).Base())
+	s.flatten(d, config, check)
+
+	return nil
+}
+
+func (s checkSpec[T, PT]) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := m.(*Config)
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	check, err := s.expandCheck(d, config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	check.Base().Reconfigure = d.HasChanges(s.reconfigureOn...)
+	if err := validateNotificationChannels(ctx, config.Client, check.Base()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := s.service(config.Client.Checks).Update(ctx, id, check); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return s.read(ctx, d, m)
+}
+
+func (s checkSpec[T, PT]) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := s.service(client.Checks).Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func (s checkSpec[T, PT]) expandCheck(d *schema.ResourceData, config *Config) (PT, error) {
+	check := PT(new(T))
+	*check.Base() = expandCheckBase(d, config)
+	if err := s.expand(d, config, check); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
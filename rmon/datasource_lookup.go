@@ -0,0 +1,71 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// lookupSpec describes how a singular data source finds its object: by `id`
+// when it is set, otherwise by listing every object and keeping the one that
+// matches.
+type lookupSpec[T any] struct {
+	// kind and plural name the object in errors, e.g. "region" and "regions".
+	kind   string
+	plural string
+	// criteria describes what match looks for in errors, e.g. "name 'eu'".
+	criteria string
+	// narrowBy are the attributes that tell several matches apart.
+	narrowBy []string
+
+	get   func(ctx context.Context, id int) (*T, error)
+	list  func(ctx context.Context) ([]T, error)
+	match func(item *T) bool
+	// id returns the ID field of the object, which RMON leaves out of the
+	// response to a get by ID.
+	id func(item *T) *int
+}
+
+func lookupObject[T any](ctx context.Context, d *schema.ResourceData, spec lookupSpec[T]) (*T, diag.Diagnostics) {
+	if id, ok := d.GetOk(IDField); ok {
+		objectID, err := parseID(id.(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		object, err := spec.get(ctx, objectID)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		*spec.id(object) = objectID
+		return object, nil
+	}
+
+	objects, err := spec.list(ctx)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var matches []*T
+	for i := range objects {
+		if spec.match(&objects[i]) {
+			matches = append(matches, &objects[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, diag.Errorf("%s with %s not found", spec.kind, spec.criteria)
+	case 1:
+		return matches[0], nil
+	default:
+		narrowBy := make([]string, 0, len(spec.narrowBy))
+		for _, field := range spec.narrowBy {
+			narrowBy = append(narrowBy, fmt.Sprintf("`%s`", field))
+		}
+		return nil, diag.Errorf("found %d %s with %s, set %s to select one", len(matches), spec.plural, spec.criteria, strings.Join(narrowBy, " or "))
+	}
+}
@@ -0,0 +1,121 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
+)
+
+const (
+	StatusField  = "status"
+	VersionField = "version"
+)
+
+func dataSourceAgent() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAgentRead,
+		Description: "Looks up an RMON agent by its ID or name, together with its live status. Use it to pass agent IDs to the `entities` of checks with `place = \"agent\"`.",
+
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, NameField},
+				Description:  "ID of the Agent. Exactly one of `id` and `name` must be set.",
+			},
+			NameField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, NameField},
+				Description:  "Name of the Agent. Exactly one of `id` and `name` must be set.",
+			},
+			DescriptionField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the Agent.",
+			},
+			EnabledField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Enabled state of the Agent.",
+			},
+			SharedField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is the Agent shared with other groups?.",
+			},
+			ServerIdField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the server where Agent is installed.",
+			},
+			PortField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Port number the Agent is bound to.",
+			},
+			RegionIdFiled: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the region to which the agent belongs.",
+			},
+			StatusField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the Agent as seen by RMON: `online` or `offline`.",
+			},
+			VersionField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version the Agent is running.",
+			},
+		},
+	}
+}
+
+func dataSourceAgentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	name := d.Get(NameField).(string)
+
+	agent, diags := lookupObject(ctx, d, lookupSpec[rmonapi.Agent]{
+		kind:     "agent",
+		plural:   "agents",
+		criteria: fmt.Sprintf("name '%s'", name),
+		narrowBy: []string{IDField},
+		get:      client.Agents.Get,
+		list:     client.Agents.List,
+		match: func(agent *rmonapi.Agent) bool {
+			return strings.ReplaceAll(agent.Name, "'", "") == name
+		},
+		id: func(agent *rmonapi.Agent) *int { return &agent.ID },
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	status, err := client.Agents.Status(ctx, agent.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(agent.ID))
+	d.Set(NameField, strings.ReplaceAll(agent.Name, "'", ""))
+	d.Set(DescriptionField, strings.ReplaceAll(agent.Description, "'", ""))
+	d.Set(EnabledField, bool(agent.Enabled))
+	d.Set(SharedField, bool(agent.Shared))
+	d.Set(ServerIdField, agent.ServerID)
+	d.Set(PortField, agent.Port)
+	d.Set(RegionIdFiled, agent.RegionID)
+	d.Set(StatusField, status.Status)
+	d.Set(VersionField, status.Version)
+
+	return nil
+}
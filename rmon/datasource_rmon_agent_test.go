@@ -0,0 +1,73 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+// testAccSeedAgents registers agents in the fake RMON. The agent in region 2
+// that is not shared is offline and runs an older version.
+func testAccSeedAgents(srv *rmontest.Server) {
+	srv.Create(rmontest.KindAgent, rmontest.Object{"name": "agent-eu-1", "server_id": 1, "region_id": 1, "port": 5101, "shared": 1, "enabled": 1, "description": "eu"})
+	srv.Create(rmontest.KindAgent, rmontest.Object{"name": "agent-eu-2", "server_id": 2, "region_id": 1, "port": 5101, "shared": 0, "enabled": 1, "description": "eu"})
+	srv.Create(rmontest.KindAgent, rmontest.Object{"name": "agent-us-1", "server_id": 3, "region_id": 2, "port": 5102, "shared": 1, "enabled": 1, "description": "us"})
+	offline := srv.Create(rmontest.KindAgent, rmontest.Object{"name": "agent-us-2", "server_id": 4, "region_id": 2, "port": 5102, "shared": 0, "enabled": 1, "description": "us"})
+	srv.SetAgentStatus(offline, "offline", "1.1.0")
+}
+
+func TestAccDataSourceAgent(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedAgents(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_agent" "by_id" {
+  id = "1"
+}
+
+data "rmon_agent" "by_name" {
+  name = "agent-us-2"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "name", "agent-eu-1"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "server_id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "region_id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "port", "5101"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "shared", "true"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "status", "online"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_id", "version", rmontest.DefaultAgentVersion),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_name", "id", "4"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_name", "shared", "false"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_name", "status", "offline"),
+					resource.TestCheckResourceAttr("data.rmon_agent.by_name", "version", "1.1.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAgent_notFound(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedAgents(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_agent" "test" {
+  name = "agent-missing"
+}
+`),
+				ExpectError: regexp.MustCompile(`agent with name 'agent-missing' not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package rmon
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
+const AgentsField = "agents"
+
+func dataSourceAgents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAgentsRead,
+		Description: "Lists RMON agents with their live status, optionally filtered by region, shared flag and status. For example, `status = \"online\"` and `region_id` select all healthy agents of a region. The status is fetched with one request per agent, after the region and shared filters are applied, so set those filters to keep large fleets fast.",
+
+		Schema: map[string]*schema.Schema{
+			RegionIdFiled: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list agents of this region.",
+			},
+			SharedField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list shared agents when `true`, or agents that are not shared when `false`. All agents are listed when unset.",
+			},
+			StatusField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list agents with this status: `online` or `offline`.",
+				ValidateFunc: validation.StringInSlice([]string{rmonapi.AgentOnline, rmonapi.AgentOffline}, false),
+			},
+			AgentsField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of agents.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the Agent.",
+						},
+						NameField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the Agent.",
+						},
+						ServerIdField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the server where Agent is installed.",
+						},
+						RegionIdFiled: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the region to which the agent belongs.",
+						},
+						PortField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Port number the Agent is bound to.",
+						},
+						SharedField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Is the Agent shared with other groups?.",
+						},
+						EnabledField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enabled state of the Agent.",
+						},
+						StatusField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Current status of the Agent: `online` or `offline`.",
+						},
+						VersionField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version the Agent is running.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAgentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	regionID, filterRegion := d.GetOk(RegionIdFiled)
+	shared, filterShared := getOptionalBool(d, SharedField)
+	wantStatus, filterStatus := d.GetOk(StatusField)
+
+	agents, err := client.Agents.List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	items := make([]map[string]interface{}, 0, len(agents))
+	for _, agent := range agents {
+		// Filter before asking for the status, which costs a request per
+		// agent.
+		if filterRegion && agent.RegionID != regionID.(int) {
+			continue
+		}
+		if filterShared && bool(agent.Shared) != shared {
+			continue
+		}
+
+		status, err := client.Agents.Status(ctx, agent.ID)
+		if err != nil {
+			// The agent was deleted after it was listed.
+			if rmonapi.IsNotFound(err) {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		if filterStatus && status.Status != wantStatus.(string) {
+			continue
+		}
+
+		items = append(items, map[string]interface{}{
+			IDField:       agent.ID,
+			NameField:     strings.ReplaceAll(agent.Name, "'", ""),
+			ServerIdField: agent.ServerID,
+			RegionIdFiled: agent.RegionID,
+			PortField:     agent.Port,
+			SharedField:   bool(agent.Shared),
+			EnabledField:  bool(agent.Enabled),
+			StatusField:   status.Status,
+			VersionField:  status.Version,
+		})
+	}
+
+	if err := d.Set(AgentsField, items); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(AgentsField)
+	return nil
+}
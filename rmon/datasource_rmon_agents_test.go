@@ -0,0 +1,47 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAgents(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedAgents(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_agents" "all" {}
+
+data "rmon_agents" "shared" {
+  shared = true
+}
+
+data "rmon_agents" "not_shared" {
+  shared = false
+}
+
+data "rmon_agents" "healthy_us" {
+  region_id = 2
+  status    = "online"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_agents.all", "agents.#", "4"),
+					resource.TestCheckResourceAttr("data.rmon_agents.shared", "agents.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_agents.not_shared", "agents.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_agents.healthy_us", "agents.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_agents.healthy_us", "agents.0.id", "3"),
+					resource.TestCheckResourceAttr("data.rmon_agents.healthy_us", "agents.0.name", "agent-us-1"),
+					resource.TestCheckResourceAttr("data.rmon_agents.healthy_us", "agents.0.server_id", "3"),
+					resource.TestCheckResourceAttr("data.rmon_agents.healthy_us", "agents.0.port", "5102"),
+					resource.TestCheckResourceAttr("data.rmon_agents.healthy_us", "agents.0.status", "online"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
+func dataSourceChannel() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceChannelRead,
+		Description: "Looks up an existing RMON channel by its ID or by its name, for example to route the alerts of a check to a channel managed in another workspace.",
+
+		Schema: map[string]*schema.Schema{
+			ReceiverField: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The type of the receiver of the channel. One of " + quoteList(channelReceiverNames()) + ".",
+				ValidateFunc: validation.StringInSlice(channelReceiverNames(), true),
+			},
+			IDField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, ChannelField},
+				Description:  "ID of the channel. Exactly one of `channel` and `id` must be set.",
+			},
+			ChannelField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, ChannelField},
+				Description:  "The channel identifier. Exactly one of `channel` and `id` must be set.",
+			},
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the group to which the channel belongs. Narrows down a lookup by `channel`.",
+			},
+		},
+	}
+}
+
+func dataSourceChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+	receiver := strings.ToLower(d.Get(ReceiverField).(string))
+
+	name := d.Get(ChannelField).(string)
+	groupID, filterGroup := d.GetOk(GroupIDField)
+
+	channel, diags := lookupObject(ctx, d, lookupSpec[rmonapi.Channel]{
+		kind:     receiver + " channel",
+		plural:   receiver + " channels",
+		criteria: fmt.Sprintf("name '%s'", name),
+		narrowBy: []string{GroupIDField, IDField},
+		get: func(ctx context.Context, id int) (*rmonapi.Channel, error) {
+			return client.Channels.Get(ctx, receiver, id)
+		},
+		list: func(ctx context.Context) ([]rmonapi.Channel, error) {
+			return client.Channels.List(ctx, receiver)
+		},
+		match: func(channel *rmonapi.Channel) bool {
+			return channel.Channel == name && (!filterGroup || channel.GroupID == groupID.(int))
+		},
+		id: func(channel *rmonapi.Channel) *int { return &channel.ID },
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return setChannelDataSource(d, receiver, channel)
+}
+
+func setChannelDataSource(d *schema.ResourceData, receiver string, channel *rmonapi.Channel) diag.Diagnostics {
+	d.SetId(strconv.Itoa(channel.ID))
+	d.Set(ReceiverField, receiver)
+	d.Set(ChannelField, strings.ReplaceAll(channel.Channel, "'", ""))
+	d.Set(GroupIDField, channel.GroupID)
+	return nil
+}
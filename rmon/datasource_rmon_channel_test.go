@@ -0,0 +1,101 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testAccDataSourceChannelResources = `
+resource "rmon_group" "other" {
+  name = "other"
+}
+
+resource "rmon_channel" "alerts" {
+  receiver = "telegram"
+  channel  = "alerts"
+  group_id = 1
+  token    = "bot-token"
+}
+
+resource "rmon_channel" "other_alerts" {
+  receiver = "telegram"
+  channel  = "alerts"
+  group_id = rmon_group.other.id
+  token    = "bot-token"
+}
+
+resource "rmon_channel" "critical" {
+  receiver = "slack"
+  channel  = "critical"
+  group_id = 1
+  token    = "bot-token"
+}
+`
+
+func TestAccDataSourceChannel(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccDataSourceChannelResources+`
+data "rmon_channel" "by_id" {
+  receiver = "slack"
+  id       = rmon_channel.critical.id
+}
+
+data "rmon_channel" "by_name" {
+  receiver = "slack"
+  channel  = rmon_channel.critical.channel
+}
+
+data "rmon_channel" "by_name_and_group" {
+  receiver = "telegram"
+  channel  = rmon_channel.other_alerts.channel
+  group_id = rmon_channel.other_alerts.group_id
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_channel.by_id", "channel", "critical"),
+					resource.TestCheckResourceAttr("data.rmon_channel.by_id", "group_id", "1"),
+					resource.TestCheckResourceAttrPair("data.rmon_channel.by_name", "id", "rmon_channel.critical", "id"),
+					resource.TestCheckResourceAttrPair("data.rmon_channel.by_name_and_group", "id", "rmon_channel.other_alerts", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceChannel_ambiguousName(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccDataSourceChannelResources),
+			},
+			{
+				Config: testAccConfig(srv, testAccDataSourceChannelResources+`
+data "rmon_channel" "test" {
+  receiver = "telegram"
+  channel  = "alerts"
+}
+`),
+				ExpectError: regexp.MustCompile(`found 2 telegram channels with name 'alerts'`),
+			},
+			{
+				Config: testAccConfig(srv, testAccDataSourceChannelResources+`
+data "rmon_channel" "test" {
+  receiver = "slack"
+  channel  = "missing"
+}
+`),
+				ExpectError: regexp.MustCompile(`slack channel with name 'missing' not found`),
+			},
+		},
+	})
+}
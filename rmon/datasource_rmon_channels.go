@@ -0,0 +1,104 @@
+package rmon
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
+const ChannelsField = "channels"
+
+func dataSourceChannels() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceChannelsRead,
+		Description: "Lists RMON channels, optionally filtered by receiver type and group.",
+
+		Schema: map[string]*schema.Schema{
+			ReceiverField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list channels of this receiver type. All receiver types are listed when unset. One of " + quoteList(channelReceiverNames()) + ".",
+				ValidateFunc: validation.StringInSlice(channelReceiverNames(), true),
+			},
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list channels of this group.",
+			},
+			ChannelsField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of channels.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the channel.",
+						},
+						ReceiverField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the receiver.",
+						},
+						ChannelField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The channel identifier.",
+						},
+						GroupIDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the group to which the channel belongs.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceChannelsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	receivers := channelReceiverNames()
+	if receiver, ok := d.GetOk(ReceiverField); ok {
+		receivers = []string{strings.ToLower(receiver.(string))}
+	}
+	groupID, filterGroup := d.GetOk(GroupIDField)
+
+	items := make([]map[string]interface{}, 0)
+	for _, receiver := range receivers {
+		channels, err := client.Channels.List(ctx, receiver)
+		if err != nil {
+			// Older RMON versions do not know every receiver type.
+			if rmonapi.IsNotFound(err) && len(receivers) > 1 {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+
+		for _, channel := range channels {
+			if filterGroup && channel.GroupID != groupID.(int) {
+				continue
+			}
+			items = append(items, map[string]interface{}{
+				IDField:       channel.ID,
+				ReceiverField: receiver,
+				ChannelField:  strings.ReplaceAll(channel.Channel, "'", ""),
+				GroupIDField:  channel.GroupID,
+			})
+		}
+	}
+
+	if err := d.Set(ChannelsField, items); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ChannelsField)
+	return nil
+}
@@ -0,0 +1,43 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceChannels(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccDataSourceChannelResources),
+			},
+			{
+				Config: testAccConfig(srv, testAccDataSourceChannelResources+`
+data "rmon_channels" "all" {}
+
+data "rmon_channels" "telegram" {
+  receiver = "telegram"
+}
+
+data "rmon_channels" "default_group" {
+  group_id = 1
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_channels.all", "channels.#", "3"),
+					resource.TestCheckResourceAttr("data.rmon_channels.telegram", "channels.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_channels.default_group", "channels.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.rmon_channels.default_group", "channels.*", map[string]string{
+						"receiver": "slack",
+						"channel":  "critical",
+						"group_id": "1",
+					}),
+				),
+			},
+		},
+	})
+}
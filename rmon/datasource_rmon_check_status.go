@@ -0,0 +1,141 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
+const (
+	CheckIDField      = "check_id"
+	AgentIDField      = "agent_id"
+	LastCheckField    = "last_check"
+	ResponseTimeField = "response_time"
+	ErrorField        = "error"
+)
+
+var checkStatuses = []string{rmonapi.CheckUp, rmonapi.CheckDown, rmonapi.CheckUnknown}
+
+func dataSourceCheckStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCheckStatusRead,
+		Description: "Reads the current state of an RMON check, for example to gate a deployment on an endpoint being up. The state is read on every refresh.",
+
+		Schema: map[string]*schema.Schema{
+			TypeField: {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Type of the check. One of " + quoteList(checkListerTypes()) + ".",
+				ValidateFunc: validation.StringInSlice(checkListerTypes(), false),
+			},
+			CheckIDField: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the check.",
+			},
+			StatusField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current status of the check. One of " + quoteList(checkStatuses) + ". A check is `unknown` until an agent has run it.",
+			},
+			LastCheckField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last run in RFC 3339 format. Empty if the check has not run yet.",
+			},
+			ResponseTimeField: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Response time of the last run in milliseconds.",
+			},
+			ErrorField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Error message of the last failed run.",
+			},
+			AgentsField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Last result of the check on each agent running it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						AgentIDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the agent.",
+						},
+						StatusField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the check on the agent.",
+						},
+						LastCheckField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the last run on the agent in RFC 3339 format.",
+						},
+						ResponseTimeField: {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Response time of the last run on the agent in milliseconds.",
+						},
+						ErrorField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Error message of the last failed run on the agent.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCheckStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+	checkType := d.Get(TypeField).(string)
+	checkID := d.Get(CheckIDField).(int)
+
+	status, err := client.Checks.Status(ctx, checkType, checkID)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			return diag.Errorf("%s check %d not found", checkType, checkID)
+		}
+		return diag.FromErr(err)
+	}
+
+	agents := make([]map[string]interface{}, 0, len(status.Agents))
+	for _, result := range status.Agents {
+		agents = append(agents, map[string]interface{}{
+			AgentIDField:      result.AgentID,
+			StatusField:       checkStatusOrUnknown(result.Status),
+			LastCheckField:    result.LastCheck,
+			ResponseTimeField: result.ResponseTime,
+			ErrorField:        result.Error,
+		})
+	}
+	if err := d.Set(AgentsField, agents); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set(StatusField, checkStatusOrUnknown(status.Status))
+	d.Set(LastCheckField, status.LastCheck)
+	d.Set(ResponseTimeField, status.ResponseTime)
+	d.Set(ErrorField, status.Error)
+
+	d.SetId(fmt.Sprintf("%s/%d", checkType, checkID))
+	return nil
+}
+
+// checkStatusOrUnknown maps the statuses this provider does not know, like
+// an empty one, to unknown.
+func checkStatusOrUnknown(status string) string {
+	if containsString(checkStatuses, status) {
+		return status
+	}
+	return rmonapi.CheckUnknown
+}
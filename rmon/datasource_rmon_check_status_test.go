@@ -0,0 +1,78 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccDataSourceCheckStatus(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedChecks(srv)
+	srv.SetCheckStatus("http", 1, rmontest.Object{
+		"status":        "down",
+		"last_check":    "2026-10-18T05:00:00Z",
+		"response_time": 1520.5,
+		"error":         "HTTP 503",
+		"agents": []interface{}{
+			map[string]interface{}{"agent_id": 1, "status": "up", "last_check": "2026-10-18T05:00:00Z", "response_time": 41.2},
+			map[string]interface{}{"agent_id": 2, "status": "down", "last_check": "2026-10-18T04:59:58Z", "response_time": 3000, "error": "HTTP 503"},
+		},
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_check_status" "api" {
+  type     = "http"
+  check_id = 1
+}
+
+data "rmon_check_status" "gateway" {
+  type     = "ping"
+  check_id = 1
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "status", "down"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "last_check", "2026-10-18T05:00:00Z"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "response_time", "1520.5"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "error", "HTTP 503"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "agents.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "agents.0.agent_id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "agents.0.status", "up"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "agents.0.error", ""),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "agents.1.status", "down"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.api", "agents.1.response_time", "3000"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.gateway", "status", "unknown"),
+					resource.TestCheckResourceAttr("data.rmon_check_status.gateway", "last_check", ""),
+					resource.TestCheckResourceAttr("data.rmon_check_status.gateway", "agents.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceCheckStatus_notFound(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedChecks(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_check_status" "test" {
+  type     = "tcp"
+  check_id = 42
+}
+`),
+				ExpectError: regexp.MustCompile("tcp check 42 not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,296 @@
+package rmon
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
+)
+
+const (
+	ChecksField    = "checks"
+	TypeField      = "type"
+	TargetField    = "target"
+	NameRegexField = "name_regex"
+)
+
+// checkLister lists the checks of one type as data source items.
+type checkLister struct {
+	checkType string
+	list      func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error)
+}
+
+// checkListers covers every check type, in the order they are listed.
+var checkListers = []checkLister{
+	{
+		checkType: "http",
+		list: func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error) {
+			return listChecks(ctx, checks.HTTP, func(check *rmonapi.HTTPCheck) string {
+				return check.URL
+			})
+		},
+	},
+	{
+		checkType: "tcp",
+		list: func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error) {
+			return listChecks(ctx, checks.TCP, func(check *rmonapi.TCPCheck) string {
+				return net.JoinHostPort(check.IP, strconv.Itoa(check.Port))
+			})
+		},
+	},
+	{
+		checkType: "ping",
+		list: func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error) {
+			return listChecks(ctx, checks.Ping, func(check *rmonapi.PingCheck) string {
+				return check.IP
+			})
+		},
+	},
+	{
+		checkType: "dns",
+		list: func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error) {
+			return listChecks(ctx, checks.DNS, func(check *rmonapi.DNSCheck) string {
+				return net.JoinHostPort(check.IP, strconv.Itoa(check.Port))
+			})
+		},
+	},
+	{
+		checkType: "smtp",
+		list: func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error) {
+			return listChecks(ctx, checks.SMTP, func(check *rmonapi.SMTPCheck) string {
+				return net.JoinHostPort(check.IP, strconv.Itoa(check.Port))
+			})
+		},
+	},
+	{
+		checkType: "rabbitmq",
+		list: func(ctx context.Context, checks *rmonapi.CheckServices) ([]map[string]interface{}, error) {
+			return listChecks(ctx, checks.RabbitMQ, func(check *rmonapi.RabbitMQCheck) string {
+				return net.JoinHostPort(check.IP, strconv.Itoa(check.Port))
+			})
+		},
+	},
+}
+
+func checkListerTypes() []string {
+	types := make([]string, 0, len(checkListers))
+	for _, lister := range checkListers {
+		types = append(types, lister.checkType)
+	}
+	return types
+}
+
+func dataSourceChecks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceChecksRead,
+		Description: "Lists RMON checks of all types, including checks that are not managed by Terraform. The filters are combined.",
+
+		Schema: map[string]*schema.Schema{
+			TypeField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list checks of this type. One of " + quoteList(checkListerTypes()) + ".",
+				ValidateFunc: validation.StringInSlice(checkListerTypes(), false),
+			},
+			CheckGroupIdFiled: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list checks of the check group with this name.",
+			},
+			EnabledField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list enabled checks when `true`, or disabled checks when `false`. All checks are listed when unset.",
+			},
+			NameRegexField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list checks whose name matches this regular expression.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			PlaceField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list checks created in this place: `all`, `country`, `region` or `agent`.",
+				ValidateFunc: validation.StringInSlice([]string{"all", "country", "region", "agent"}, false),
+			},
+			ChecksField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of checks.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the check. IDs are only unique within a check type.",
+						},
+						TypeField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the check.",
+						},
+						NameField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the check.",
+						},
+						TargetField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "What the check monitors: the URL of HTTP checks, the IP of Ping checks and `ip:port` for the other types.",
+						},
+						EnabledField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enabled state of the check.",
+						},
+						CheckGroupIdFiled: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the check group of the check.",
+						},
+						PlaceField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Where the check is created.",
+						},
+						ChannelsField: {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Channels the alerts of the check are sent to.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									ReceiverField: {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the receiver of the channel.",
+									},
+									ChannelIDField: {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "ID of the channel.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceChecksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	checkType, filterType := d.GetOk(TypeField)
+	checkGroup, filterCheckGroup := d.GetOk(CheckGroupIdFiled)
+	enabled, filterEnabled := getOptionalBool(d, EnabledField)
+	place, filterPlace := d.GetOk(PlaceField)
+
+	var nameRegex *regexp.Regexp
+	if pattern, ok := d.GetOk(NameRegexField); ok {
+		var err error
+		if nameRegex, err = regexp.Compile(pattern.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	items := make([]map[string]interface{}, 0)
+	for _, lister := range checkListers {
+		if filterType && lister.checkType != checkType.(string) {
+			continue
+		}
+
+		checks, err := lister.list(ctx, client.Checks)
+		if err != nil {
+			// Older RMON versions do not know every check type.
+			if rmonapi.IsNotFound(err) && !filterType {
+				continue
+			}
+			return diag.Errorf("listing %s checks: %s", lister.checkType, err)
+		}
+
+		for _, check := range checks {
+			check[TypeField] = lister.checkType
+			if filterCheckGroup && check[CheckGroupIdFiled] != checkGroup.(string) {
+				continue
+			}
+			if filterEnabled && check[EnabledField] != enabled {
+				continue
+			}
+			if filterPlace && check[PlaceField] != place.(string) {
+				continue
+			}
+			if nameRegex != nil && !nameRegex.MatchString(check[NameField].(string)) {
+				continue
+			}
+			items = append(items, check)
+		}
+	}
+
+	if err := d.Set(ChecksField, items); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ChecksField)
+	return nil
+}
+
+func listChecks[T any, PT interface {
+	*T
+	rmonapi.Check
+}](ctx context.Context, service *rmonapi.CheckService[T], target func(PT) string) ([]map[string]interface{}, error) {
+	checks, err := service.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(checks))
+	for i := range checks {
+		check := PT(&checks[i])
+		base := check.Base()
+		items = append(items, map[string]interface{}{
+			IDField:           base.ID,
+			NameField:         strings.ReplaceAll(base.Name, "'", ""),
+			TargetField:       target(check),
+			EnabledField:      bool(base.Enabled),
+			CheckGroupIdFiled: base.CheckGroup,
+			PlaceField:        base.Place,
+			ChannelsField:     checkChannels(base),
+		})
+	}
+	return items, nil
+}
+
+// checkChannels returns the channels a check alerts, from the notification
+// list or, on servers that predate it, from the per-receiver channel IDs.
+func checkChannels(check *rmonapi.CheckBase) []map[string]interface{} {
+	channels := make([]map[string]interface{}, 0)
+	for _, notification := range check.NotificationList() {
+		channels = append(channels, map[string]interface{}{
+			ReceiverField:  notification.Receiver,
+			ChannelIDField: notification.ChannelID,
+		})
+	}
+	if len(channels) > 0 {
+		return channels
+	}
+
+	for _, receiver := range notificationReceivers {
+		if id := receiver.id(check); id != 0 {
+			channels = append(channels, map[string]interface{}{
+				ReceiverField:  receiver.name,
+				ChannelIDField: id,
+			})
+		}
+	}
+	return channels
+}
@@ -0,0 +1,113 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+// testAccSeedChecks registers checks of several types in the fake RMON, as
+// if they had been created through the UI.
+func testAccSeedChecks(srv *rmontest.Server) {
+	srv.Create("rmon/check/http", rmontest.Object{
+		"name": "api-health", "url": "https://api.example.com/health", "enabled": 1, "place": "all", "check_group": "api",
+		"notifications": []interface{}{
+			map[string]interface{}{"receiver": "slack", "channel_id": 3},
+			map[string]interface{}{"receiver": "email", "channel_id": 1, "severity": "critical"},
+		},
+	})
+	srv.Create("rmon/check/http", rmontest.Object{
+		"name": "www", "url": "https://www.example.com", "enabled": 0, "place": "region", "entities": []interface{}{1},
+	})
+	srv.Create("rmon/check/tcp", rmontest.Object{
+		"name": "api-db", "ip": "10.0.0.5", "port": 5432, "enabled": 1, "place": "agent", "entities": []interface{}{2}, "check_group": "api",
+		"telegram_channel_id": 7,
+	})
+	srv.Create("rmon/check/ping", rmontest.Object{
+		"name": "gateway", "ip": "10.0.0.1", "enabled": 1, "place": "all",
+	})
+	srv.Create("rmon/check/dns", rmontest.Object{
+		"name": "resolver", "ip": "example.com", "port": 53, "enabled": 1, "place": "all",
+	})
+}
+
+func TestAccDataSourceChecks(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedChecks(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_checks" "all" {}
+
+data "rmon_checks" "http" {
+  type = "http"
+}
+
+data "rmon_checks" "api" {
+  check_group = "api"
+  name_regex  = "^api-"
+}
+
+data "rmon_checks" "disabled" {
+  enabled = false
+}
+
+data "rmon_checks" "agent" {
+  place = "agent"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_checks.all", "checks.#", "5"),
+					resource.TestCheckResourceAttr("data.rmon_checks.http", "checks.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.0.type", "http"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.0.name", "api-health"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.0.target", "https://api.example.com/health"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.0.channels.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.0.channels.0.receiver", "slack"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.0.channels.0.channel_id", "3"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.1.type", "tcp"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.1.target", "10.0.0.5:5432"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.1.channels.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.1.channels.0.receiver", "telegram"),
+					resource.TestCheckResourceAttr("data.rmon_checks.api", "checks.1.channels.0.channel_id", "7"),
+					resource.TestCheckResourceAttr("data.rmon_checks.disabled", "checks.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_checks.disabled", "checks.0.name", "www"),
+					resource.TestCheckResourceAttr("data.rmon_checks.agent", "checks.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_checks.agent", "checks.0.id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_checks.agent", "checks.0.name", "api-db"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceChecks_includesManagedChecks(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedChecks(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckPingConfig("managed", 60, "10.0.0.9")),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckPingConfig("managed", 60, "10.0.0.9")+`
+data "rmon_checks" "ping" {
+  type = "ping"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_checks.ping", "checks.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_checks.ping", "checks.1.name", "managed"),
+					resource.TestCheckResourceAttrPair("data.rmon_checks.ping", "checks.1.id", "rmon_check_ping.test", "id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,107 @@
+package rmon
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const CountriesField = "countries"
+
+func dataSourceCountries() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCountriesRead,
+		Description: "Lists RMON countries, optionally filtered by group and shared flag.",
+
+		Schema: map[string]*schema.Schema{
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list countries of this group.",
+			},
+			SharedField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list shared countries when `true`, or countries that are not shared when `false`. All countries are listed when unset.",
+			},
+			CountriesField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of countries.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the Country.",
+						},
+						NameField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the Country.",
+						},
+						DescriptionField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the Country.",
+						},
+						EnabledField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enabled state of the Country.",
+						},
+						SharedField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Is the Country shared with other groups?.",
+						},
+						GroupIDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Group ID.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCountriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	groupID, filterGroup := d.GetOk(GroupIDField)
+	shared, filterShared := getOptionalBool(d, SharedField)
+
+	countries, err := client.Countries.List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	items := make([]map[string]interface{}, 0, len(countries))
+	for _, country := range countries {
+		if filterGroup && country.GroupID != groupID.(int) {
+			continue
+		}
+		if filterShared && bool(country.Shared) != shared {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			IDField:          country.ID,
+			NameField:        strings.ReplaceAll(country.Name, "'", ""),
+			DescriptionField: strings.ReplaceAll(country.Description, "'", ""),
+			EnabledField:     bool(country.Enabled),
+			SharedField:      bool(country.Shared),
+			GroupIDField:     country.GroupID,
+		})
+	}
+
+	if err := d.Set(CountriesField, items); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(CountriesField)
+	return nil
+}
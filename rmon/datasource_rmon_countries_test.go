@@ -0,0 +1,39 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCountries(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedRegions(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_countries" "all" {}
+
+data "rmon_countries" "shared" {
+  shared = true
+}
+
+data "rmon_countries" "other_group" {
+  group_id = 2
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_countries.all", "countries.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_countries.shared", "countries.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_countries.shared", "countries.0.name", "Germany"),
+					resource.TestCheckResourceAttr("data.rmon_countries.other_group", "countries.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_countries.other_group", "countries.0.id", "2"),
+					resource.TestCheckResourceAttr("data.rmon_countries.other_group", "countries.0.name", "USA"),
+				),
+			},
+		},
+	})
+}
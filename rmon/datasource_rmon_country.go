@@ -0,0 +1,87 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
+)
+
+func dataSourceCountry() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCountryRead,
+		Description: "Looks up an RMON country by its ID or name. Use it to pass country IDs to the `entities` of checks with `place = \"country\"`.",
+
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, NameField},
+				Description:  "ID of the Country. Exactly one of `id` and `name` must be set.",
+			},
+			NameField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, NameField},
+				Description:  "Name of the Country. Exactly one of `id` and `name` must be set.",
+			},
+			DescriptionField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the Country.",
+			},
+			EnabledField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Enabled state of the Country.",
+			},
+			SharedField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is the Country shared with other groups?.",
+			},
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Group ID.",
+			},
+		},
+	}
+}
+
+func dataSourceCountryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	name := d.Get(NameField).(string)
+
+	country, diags := lookupObject(ctx, d, lookupSpec[rmonapi.Country]{
+		kind:     "country",
+		plural:   "countries",
+		criteria: fmt.Sprintf("name '%s'", name),
+		narrowBy: []string{IDField},
+		get:      client.Countries.Get,
+		list:     client.Countries.List,
+		match: func(country *rmonapi.Country) bool {
+			return strings.ReplaceAll(country.Name, "'", "") == name
+		},
+		id: func(country *rmonapi.Country) *int { return &country.ID },
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(country.ID))
+	d.Set(NameField, strings.ReplaceAll(country.Name, "'", ""))
+	d.Set(DescriptionField, strings.ReplaceAll(country.Description, "'", ""))
+	d.Set(EnabledField, bool(country.Enabled))
+	d.Set(SharedField, bool(country.Shared))
+	d.Set(GroupIDField, country.GroupID)
+
+	return nil
+}
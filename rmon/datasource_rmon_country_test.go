@@ -0,0 +1,49 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCountry(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedRegions(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_country" "by_id" {
+  id = "1"
+}
+
+data "rmon_country" "by_name" {
+  name = "USA"
+}
+
+data "rmon_region" "test" {
+  name = "us-east"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_country.by_id", "name", "Germany"),
+					resource.TestCheckResourceAttr("data.rmon_country.by_id", "shared", "true"),
+					resource.TestCheckResourceAttr("data.rmon_country.by_name", "description", "US"),
+					resource.TestCheckResourceAttr("data.rmon_country.by_name", "group_id", "2"),
+					resource.TestCheckResourceAttrPair("data.rmon_country.by_name", "id", "data.rmon_region.test", "country_id"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, `
+data "rmon_country" "test" {
+  name = "France"
+}
+`),
+				ExpectError: regexp.MustCompile("country with name 'France' not found"),
+			},
+		},
+	})
+}
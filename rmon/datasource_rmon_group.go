@@ -2,11 +2,11 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -55,54 +55,36 @@ func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interfac
 	return diag.Errorf("either 'id' or 'name' must be specified")
 }
 
-func readGroupByID(ctx context.Context, d *schema.ResourceData, client *Client, id string) diag.Diagnostics {
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/group/%s", id), nil)
+func readGroupByID(ctx context.Context, d *schema.ResourceData, client *rmonapi.Client, id string) diag.Diagnostics {
+	groupID, err := parseID(id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Process response and set data
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	group, err := client.Groups.Get(ctx, groupID)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if name, ok := result[NameField].(string); ok {
-		d.Set(NameField, name)
-	}
-
-	if description, ok := result[DescriptionField].(string); ok {
-		d.Set(DescriptionField, description)
-	}
+	d.Set(NameField, group.Name)
+	d.Set(DescriptionField, group.Description)
 
 	d.SetId(id)
 	return nil
 }
 
-func readGroupByName(ctx context.Context, d *schema.ResourceData, client *Client, name string) diag.Diagnostics {
-	resp, err := client.doRequest("GET", "/api/v1.0/groups", nil)
+func readGroupByName(ctx context.Context, d *schema.ResourceData, client *rmonapi.Client, name string) diag.Diagnostics {
+	groups, err := client.Groups.List(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Process response and find the group by name
-	var groups []map[string]interface{}
-	if err := json.Unmarshal(resp, &groups); err != nil {
-		return diag.FromErr(err)
-	}
-
 	for _, group := range groups {
-		if groupName, ok := group[NameField].(string); ok && groupName == name {
-			if id, ok := group["group_id"].(float64); ok {
-				d.SetId(fmt.Sprintf("%d", int(id)))
-				d.Set(NameField, groupName)
-
-				if description, ok := group[DescriptionField].(string); ok {
-					d.Set(DescriptionField, description)
-				}
-
-				return nil
-			}
+		if group.Name == name {
+			d.SetId(strconv.Itoa(group.ID))
+			d.Set(NameField, group.Name)
+			d.Set(DescriptionField, group.Description)
+			return nil
 		}
 	}
 
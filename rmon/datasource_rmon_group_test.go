@@ -0,0 +1,63 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGroup(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_group" "test" {
+  name        = "backend"
+  description = "Backend servers"
+}
+
+data "rmon_group" "by_id" {
+  id = rmon_group.test.id
+}
+
+data "rmon_group" "by_name" {
+  name = rmon_group.test.name
+}
+
+data "rmon_group" "default" {
+  name = "Default"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.rmon_group.by_id", "name", "rmon_group.test", "name"),
+					resource.TestCheckResourceAttr("data.rmon_group.by_id", "description", "Backend servers"),
+					resource.TestCheckResourceAttrPair("data.rmon_group.by_name", "id", "rmon_group.test", "id"),
+					resource.TestCheckResourceAttr("data.rmon_group.by_name", "description", "Backend servers"),
+					resource.TestCheckResourceAttr("data.rmon_group.default", "id", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGroup_notFound(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_group" "test" {
+  name = "missing"
+}
+`),
+				ExpectError: regexp.MustCompile(`group with name 'missing' not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
+)
+
+func dataSourceRegion() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRegionRead,
+		Description: "Looks up an RMON region by its ID or name. Use it to pass region IDs to the `entities` of checks with `place = \"region\"`.",
+
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, NameField},
+				Description:  "ID of the Region. Exactly one of `id` and `name` must be set.",
+			},
+			NameField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, NameField},
+				Description:  "Name of the Region. Exactly one of `id` and `name` must be set.",
+			},
+			DescriptionField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the Region.",
+			},
+			EnabledField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Enabled state of the Region.",
+			},
+			SharedField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is the Region shared with other groups?.",
+			},
+			CountryField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Country ID to what the Region belongs to.",
+			},
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Group ID.",
+			},
+		},
+	}
+}
+
+func dataSourceRegionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	name := d.Get(NameField).(string)
+
+	region, diags := lookupObject(ctx, d, lookupSpec[rmonapi.Region]{
+		kind:     "region",
+		plural:   "regions",
+		criteria: fmt.Sprintf("name '%s'", name),
+		narrowBy: []string{IDField},
+		get:      client.Regions.Get,
+		list:     client.Regions.List,
+		match: func(region *rmonapi.Region) bool {
+			return strings.ReplaceAll(region.Name, "'", "") == name
+		},
+		id: func(region *rmonapi.Region) *int { return &region.ID },
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(region.ID))
+	d.Set(NameField, strings.ReplaceAll(region.Name, "'", ""))
+	d.Set(DescriptionField, strings.ReplaceAll(region.Description, "'", ""))
+	d.Set(EnabledField, bool(region.Enabled))
+	d.Set(SharedField, bool(region.Shared))
+	d.Set(CountryField, region.CountryID)
+	d.Set(GroupIDField, region.GroupID)
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+// testAccSeedRegions registers two countries and three regions in the fake
+// RMON. Both groups own a region named "eu-central".
+func testAccSeedRegions(srv *rmontest.Server) {
+	de := srv.Create(rmontest.KindCountry, rmontest.Object{"name": "Germany", "description": "DE", "enabled": 1, "shared": 1, "group_id": 1})
+	us := srv.Create(rmontest.KindCountry, rmontest.Object{"name": "USA", "description": "US", "enabled": 1, "shared": 0, "group_id": 2})
+	srv.Create(rmontest.KindRegion, rmontest.Object{"name": "eu-central", "description": "Frankfurt", "enabled": 1, "shared": 1, "country_id": de, "group_id": 1})
+	srv.Create(rmontest.KindRegion, rmontest.Object{"name": "us-east", "description": "Virginia", "enabled": 1, "shared": 0, "country_id": us, "group_id": 1})
+	srv.Create(rmontest.KindRegion, rmontest.Object{"name": "eu-central", "description": "Frankfurt", "enabled": 0, "shared": 0, "country_id": de, "group_id": 2})
+}
+
+func TestAccDataSourceRegion(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedRegions(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_region" "by_id" {
+  id = "3"
+}
+
+data "rmon_region" "by_name" {
+  name = "us-east"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_region.by_id", "name", "eu-central"),
+					resource.TestCheckResourceAttr("data.rmon_region.by_id", "group_id", "2"),
+					resource.TestCheckResourceAttr("data.rmon_region.by_id", "enabled", "false"),
+					resource.TestCheckResourceAttr("data.rmon_region.by_name", "id", "2"),
+					resource.TestCheckResourceAttr("data.rmon_region.by_name", "description", "Virginia"),
+					resource.TestCheckResourceAttr("data.rmon_region.by_name", "country_id", "2"),
+					resource.TestCheckResourceAttr("data.rmon_region.by_name", "shared", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRegion_lookupErrors(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedRegions(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_region" "test" {
+  name = "eu-central"
+}
+`),
+				ExpectError: regexp.MustCompile("found 2 regions with name 'eu-central'"),
+			},
+			{
+				Config: testAccConfig(srv, `
+data "rmon_region" "test" {
+  name = "ap-south"
+}
+`),
+				ExpectError: regexp.MustCompile("region with name 'ap-south' not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,122 @@
+package rmon
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const RegionsField = "regions"
+
+func dataSourceRegions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRegionsRead,
+		Description: "Lists RMON regions, optionally filtered by group, shared flag and country.",
+
+		Schema: map[string]*schema.Schema{
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list regions of this group.",
+			},
+			SharedField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list shared regions when `true`, or regions that are not shared when `false`. All regions are listed when unset.",
+			},
+			CountryField: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list regions of this country.",
+			},
+			RegionsField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of regions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the Region.",
+						},
+						NameField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the Region.",
+						},
+						DescriptionField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the Region.",
+						},
+						EnabledField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enabled state of the Region.",
+						},
+						SharedField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Is the Region shared with other groups?.",
+						},
+						CountryField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Country ID to what the Region belongs to.",
+						},
+						GroupIDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Group ID.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRegionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	groupID, filterGroup := d.GetOk(GroupIDField)
+	shared, filterShared := getOptionalBool(d, SharedField)
+	countryID, filterCountry := d.GetOk(CountryField)
+
+	regions, err := client.Regions.List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	items := make([]map[string]interface{}, 0, len(regions))
+	for _, region := range regions {
+		if filterGroup && region.GroupID != groupID.(int) {
+			continue
+		}
+		if filterShared && bool(region.Shared) != shared {
+			continue
+		}
+		if filterCountry && region.CountryID != countryID.(int) {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			IDField:          region.ID,
+			NameField:        strings.ReplaceAll(region.Name, "'", ""),
+			DescriptionField: strings.ReplaceAll(region.Description, "'", ""),
+			EnabledField:     bool(region.Enabled),
+			SharedField:      bool(region.Shared),
+			CountryField:     region.CountryID,
+			GroupIDField:     region.GroupID,
+		})
+	}
+
+	if err := d.Set(RegionsField, items); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(RegionsField)
+	return nil
+}
@@ -0,0 +1,45 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRegions(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedRegions(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_regions" "all" {}
+
+data "rmon_regions" "default_group" {
+  group_id = 1
+}
+
+data "rmon_regions" "not_shared" {
+  shared = false
+}
+
+data "rmon_regions" "germany" {
+  country_id = 1
+  group_id   = 1
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_regions.all", "regions.#", "3"),
+					resource.TestCheckResourceAttr("data.rmon_regions.default_group", "regions.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_regions.not_shared", "regions.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_regions.germany", "regions.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_regions.germany", "regions.0.id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_regions.germany", "regions.0.name", "eu-central"),
+					resource.TestCheckResourceAttr("data.rmon_regions.germany", "regions.0.shared", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,110 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
+)
+
+func dataSourceServer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServerRead,
+		Description: "Looks up a server registered in RMON by its ID, hostname or IP address, for example to reference servers that were added through the RMON UI.",
+
+		Schema: map[string]*schema.Schema{
+			IDField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, HostnameField, IPField},
+				Description:  "ID of the server. Exactly one of `id`, `hostname` and `ip` must be set.",
+			},
+			HostnameField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, HostnameField, IPField},
+				Description:  "Hostname of the server. Exactly one of `id`, `hostname` and `ip` must be set.",
+			},
+			IPField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{IDField, HostnameField, IPField},
+				Description:  "IP address of the server. Exactly one of `id`, `hostname` and `ip` must be set.",
+			},
+			CredIDField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Credentials ID.",
+			},
+			DescriptionField: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the server.",
+			},
+			EnabledField: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Enabled state of the server.",
+			},
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Group ID.",
+			},
+			PortField: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Port number.",
+			},
+		},
+	}
+}
+
+func dataSourceServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	field, value := IPField, d.Get(IPField).(string)
+	if hostname, ok := d.GetOk(HostnameField); ok {
+		field, value = HostnameField, hostname.(string)
+	}
+
+	server, diags := lookupObject(ctx, d, lookupSpec[rmonapi.Server]{
+		kind:     "server",
+		plural:   "servers",
+		criteria: fmt.Sprintf("%s '%s'", field, value),
+		narrowBy: []string{IDField},
+		get:      client.Servers.Get,
+		list:     client.Servers.List,
+		match: func(server *rmonapi.Server) bool {
+			if field == HostnameField {
+				return strings.ReplaceAll(server.Hostname, "'", "") == value
+			}
+			return server.IP == value
+		},
+		id: func(server *rmonapi.Server) *int { return &server.ID },
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return setServerDataSource(d, server)
+}
+
+func setServerDataSource(d *schema.ResourceData, server *rmonapi.Server) diag.Diagnostics {
+	d.SetId(strconv.Itoa(server.ID))
+	d.Set(CredIDField, server.CredID)
+	d.Set(DescriptionField, strings.ReplaceAll(server.Description, "'", ""))
+	d.Set(EnabledField, bool(server.Enabled))
+	d.Set(GroupIDField, server.GroupID)
+	d.Set(HostnameField, strings.ReplaceAll(server.Hostname, "'", ""))
+	d.Set(IPField, server.IP)
+	d.Set(PortField, server.Port)
+	return nil
+}
@@ -0,0 +1,92 @@
+package rmon
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+// testAccSeedServers registers servers in the fake RMON as if they had been
+// added through the UI.
+func testAccSeedServers(srv *rmontest.Server) {
+	srv.Create(rmontest.KindServer, rmontest.Object{"hostname": "web01", "ip": "10.0.0.1", "port": 22, "cred_id": 1, "group_id": 1, "enabled": 1, "description": "frontend"})
+	srv.Create(rmontest.KindServer, rmontest.Object{"hostname": "web02", "ip": "10.0.0.2", "port": 2222, "cred_id": 1, "group_id": 2, "enabled": 0, "description": "frontend"})
+	srv.Create(rmontest.KindServer, rmontest.Object{"hostname": "db01", "ip": "10.0.0.3", "port": 22, "cred_id": 2, "group_id": 1, "enabled": 1, "description": "database"})
+	srv.Create(rmontest.KindServer, rmontest.Object{"hostname": "db01", "ip": "10.0.1.3", "port": 22, "cred_id": 2, "group_id": 2, "enabled": 1, "description": "database replica"})
+}
+
+func TestAccDataSourceServer(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedServers(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_server" "by_id" {
+  id = "2"
+}
+
+data "rmon_server" "by_hostname" {
+  hostname = "web01"
+}
+
+data "rmon_server" "by_ip" {
+  ip = "10.0.1.3"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_server.by_id", "hostname", "web02"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_id", "ip", "10.0.0.2"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_id", "port", "2222"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_id", "enabled", "false"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_hostname", "id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_hostname", "ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_hostname", "cred_id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_hostname", "description", "frontend"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_ip", "id", "4"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_ip", "hostname", "db01"),
+					resource.TestCheckResourceAttr("data.rmon_server.by_ip", "group_id", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceServer_lookupErrors(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedServers(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_server" "test" {
+  hostname = "db01"
+}
+`),
+				ExpectError: regexp.MustCompile(`found 2 servers with hostname 'db01'`),
+			},
+			{
+				Config: testAccConfig(srv, `
+data "rmon_server" "test" {
+  ip = "10.9.9.9"
+}
+`),
+				ExpectError: regexp.MustCompile(`server with ip '10.9.9.9' not found`),
+			},
+			{
+				Config: testAccConfig(srv, `
+data "rmon_server" "test" {
+  id = "42"
+}
+`),
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package rmon
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	ServersField       = "servers"
+	HostnameRegexField = "hostname_regex"
+)
+
+func dataSourceServers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServersRead,
+		Description: "Lists servers registered in RMON, optionally filtered by group, enabled state and hostname.",
+
+		Schema: map[string]*schema.Schema{
+			GroupIDField: {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list servers of this group.",
+			},
+			EnabledField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only list enabled servers when `true`, or disabled servers when `false`. All servers are listed when unset.",
+			},
+			HostnameRegexField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list servers whose hostname matches this regular expression.",
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			ServersField: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of servers.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						IDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the server.",
+						},
+						HostnameField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hostname of the server.",
+						},
+						IPField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IP address of the server.",
+						},
+						PortField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Port number.",
+						},
+						CredIDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Credentials ID.",
+						},
+						GroupIDField: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Group ID.",
+						},
+						EnabledField: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Enabled state of the server.",
+						},
+						DescriptionField: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the server.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*Config).Client
+
+	groupID, filterGroup := d.GetOk(GroupIDField)
+	enabled, filterEnabled := getOptionalBool(d, EnabledField)
+
+	var hostnameRegex *regexp.Regexp
+	if pattern, ok := d.GetOk(HostnameRegexField); ok {
+		var err error
+		if hostnameRegex, err = regexp.Compile(pattern.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	items := make([]map[string]interface{}, 0, len(servers))
+	for _, server := range servers {
+		hostname := strings.ReplaceAll(server.Hostname, "'", "")
+		if filterGroup && server.GroupID != groupID.(int) {
+			continue
+		}
+		if filterEnabled && bool(server.Enabled) != enabled {
+			continue
+		}
+		if hostnameRegex != nil && !hostnameRegex.MatchString(hostname) {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			IDField:          server.ID,
+			HostnameField:    hostname,
+			IPField:          server.IP,
+			PortField:        server.Port,
+			CredIDField:      server.CredID,
+			GroupIDField:     server.GroupID,
+			EnabledField:     bool(server.Enabled),
+			DescriptionField: strings.ReplaceAll(server.Description, "'", ""),
+		})
+	}
+
+	if err := d.Set(ServersField, items); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ServersField)
+	return nil
+}
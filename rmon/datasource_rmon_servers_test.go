@@ -0,0 +1,48 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceServers(t *testing.T) {
+	srv := testAccServer(t)
+	testAccSeedServers(srv)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_servers" "all" {}
+
+data "rmon_servers" "default_group" {
+  group_id = 1
+}
+
+data "rmon_servers" "disabled" {
+  enabled = false
+}
+
+data "rmon_servers" "web" {
+  hostname_regex = "^web"
+  enabled        = true
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_servers.all", "servers.#", "4"),
+					resource.TestCheckResourceAttr("data.rmon_servers.default_group", "servers.#", "2"),
+					resource.TestCheckResourceAttr("data.rmon_servers.disabled", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_servers.disabled", "servers.0.hostname", "web02"),
+					resource.TestCheckResourceAttr("data.rmon_servers.web", "servers.#", "1"),
+					resource.TestCheckResourceAttr("data.rmon_servers.web", "servers.0.id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_servers.web", "servers.0.ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.rmon_servers.web", "servers.0.port", "22"),
+					resource.TestCheckResourceAttr("data.rmon_servers.web", "servers.0.cred_id", "1"),
+					resource.TestCheckResourceAttr("data.rmon_servers.web", "servers.0.description", "frontend"),
+				),
+			},
+		},
+	})
+}
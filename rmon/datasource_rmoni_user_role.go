@@ -2,11 +2,11 @@ package rmon
 
 import (
 	"context"
-	"fmt"
-	"github.com/hashicorp/go-cty/cty"
-	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"strconv"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -54,32 +54,16 @@ func dataSourceUserRole() *schema.Resource {
 func dataSourceUserRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	resp, err := client.doRequest("GET", "/api/v1.0user/roles", nil)
+	roles, err := client.Roles.List(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	rolesType := cty.List(cty.Object(map[string]cty.Type{
-		RoleIDField:          cty.Number,
-		RoleNameField:        cty.String,
-		RoleDescriptionField: cty.String,
-	}))
-
-	rolesVal, err := ctyjson.Unmarshal(resp, rolesType)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if rolesVal.IsNull() || rolesVal.LengthInt() == 0 {
+	if len(roles) == 0 {
 		return diag.Errorf("No roles found")
 	}
 
-	convertedRoles, err := convertRoles(rolesVal.AsValueSlice())
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set(RolesField, convertedRoles); err != nil {
+	if err := d.Set(RolesField, flattenRoles(roles)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -87,44 +71,14 @@ func dataSourceUserRoleRead(ctx context.Context, d *schema.ResourceData, m inter
 	return nil
 }
 
-func convertRoles(roles []cty.Value) ([]map[string]interface{}, error) {
-	var convertedRoles []map[string]interface{}
-	for _, roleVal := range roles {
-		role := roleVal.AsValueMap()
-		convertedRole := make(map[string]interface{})
-
-		roleID, err := extractRoleField(role, RoleIDField)
-		if err != nil {
-			return nil, err
-		}
-		convertedRole[RoleIDField] = roleID
-
-		roleName, err := extractRoleField(role, RoleNameField)
-		if err != nil {
-			return nil, err
-		}
-		convertedRole[RoleNameField] = roleName
-
-		roleDescription, err := extractRoleField(role, RoleDescriptionField)
-		if err != nil {
-			return nil, err
-		}
-		convertedRole[RoleDescriptionField] = roleDescription
-
-		convertedRoles = append(convertedRoles, convertedRole)
-	}
-	return convertedRoles, nil
-}
-
-func extractRoleField(role map[string]cty.Value, field string) (string, error) {
-	if val, ok := role[field]; ok && !val.IsNull() {
-		switch field {
-		case RoleIDField:
-			int64Value, _ := val.AsBigFloat().Int64()
-			return fmt.Sprintf("%d", int64(int64Value)), nil
-		default:
-			return val.AsString(), nil
-		}
+func flattenRoles(roles []rmonapi.Role) []map[string]interface{} {
+	convertedRoles := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		convertedRoles = append(convertedRoles, map[string]interface{}{
+			RoleIDField:          strconv.Itoa(role.ID),
+			RoleNameField:        role.Name,
+			RoleDescriptionField: role.Description,
+		})
 	}
-	return "", fmt.Errorf("unexpected type or null value for %s field", field)
+	return convertedRoles
 }
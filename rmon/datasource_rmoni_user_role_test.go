@@ -0,0 +1,29 @@
+package rmon
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceUserRole(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+data "rmon_user_role" "test" {}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rmon_user_role.test", "roles.#", "4"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.rmon_user_role.test", "roles.*", map[string]string{
+						"role_id": "3",
+						"name":    "user",
+					}),
+				),
+			},
+		},
+	})
+}
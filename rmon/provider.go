@@ -3,19 +3,47 @@ package rmon
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
 )
 
 type Config struct {
-	Client *Client
+	Client *rmonapi.Client
+	// ServerVersion is the RMON version detected at configure time, or nil
+	// if the server did not report one.
+	ServerVersion *version.Version
 }
 
 const (
-	ProviderBaseURL = "base_url"
-	LoginField      = "login"
-	PasswordField   = "password"
+	ProviderBaseURL            = "base_url"
+	LoginField                 = "login"
+	PasswordField              = "password"
+	APITokenField              = "api_token"
+	MaxRetriesField            = "max_retries"
+	RetryMinBackoffField       = "retry_min_backoff"
+	RetryMaxBackoffField       = "retry_max_backoff"
+	RetryableStatusCodeField   = "retryable_status_codes"
+	RequestTimeoutField        = "request_timeout"
+	CACertFileField            = "ca_cert_file"
+	CACertPEMField             = "ca_cert_pem"
+	ClientCertField            = "client_cert"
+	ClientKeyField             = "client_key"
+	TLSServerNameField         = "tls_server_name"
+	InsecureSkipVerifyField    = "insecure_skip_verify"
+	ProxyURLField              = "proxy_url"
+	CustomHeadersField         = "custom_headers"
+	MaxRequestsPerSecondField  = "max_requests_per_second"
+	MaxConcurrentRequestsField = "max_concurrent_requests"
+
+	DefaultRequestTimeout = 60 * time.Second
 )
 
 func Provider() *schema.Provider {
@@ -23,22 +51,133 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			LoginField: {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Username for RMON.",
+				Optional:    true,
+				Description: fmt.Sprintf("Username for RMON. Must be set together with `%s` unless `%s` is used.", PasswordField, APITokenField),
 				DefaultFunc: schema.EnvDefaultFunc("RMON_USERNAME", nil),
 			},
 			PasswordField: {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Password for RMON.",
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Password for RMON. Must be set together with `%s` unless `%s` is used.", LoginField, APITokenField),
 				DefaultFunc: schema.EnvDefaultFunc("RMON_PASSWORD", nil),
 			},
+			APITokenField: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Long-lived RMON API token. Used instead of `%s` and `%s`; the provider does not log in when it is set.", LoginField, PasswordField),
+				DefaultFunc: schema.EnvDefaultFunc("RMON_API_TOKEN", nil),
+			},
 			ProviderBaseURL: {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "URL to connect for RMON.",
 				DefaultFunc: schema.EnvDefaultFunc("RMON_BASE_URL", nil),
 			},
+			MaxRetriesField: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  fmt.Sprintf("Maximum number of retries for transient RMON API failures. Set to 0 to disable retries. Defaults to `%d`.", rmonapi.DefaultMaxRetries),
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_MAX_RETRIES", rmonapi.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			RetryMinBackoffField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  fmt.Sprintf("Initial delay between retries, as a duration string (e.g. `500ms`, `2s`). Doubles on every attempt. Defaults to `%s`.", rmonapi.DefaultMinBackoff),
+				Default:      rmonapi.DefaultMinBackoff.String(),
+				ValidateFunc: validateDuration,
+			},
+			RetryMaxBackoffField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  fmt.Sprintf("Maximum delay between retries, as a duration string. Also caps the `Retry-After` header sent by RMON. Defaults to `%s`.", rmonapi.DefaultMaxBackoff),
+				Default:      rmonapi.DefaultMaxBackoff.String(),
+				ValidateFunc: validateDuration,
+			},
+			RetryableStatusCodeField: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "HTTP status codes that are retried for idempotent requests (GET, PUT, DELETE). Defaults to `[429, 502, 503, 504]`. `POST` and `PATCH` requests are only retried when the connection to RMON could not be established.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(100, 599),
+				},
+			},
+			RequestTimeoutField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  fmt.Sprintf("Timeout for a single RMON API call, as a duration string (e.g. `30s`). Each retry attempt gets its own timeout. Set to `0s` to rely on resource timeouts only. Defaults to `%s`.", DefaultRequestTimeout),
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_REQUEST_TIMEOUT", DefaultRequestTimeout.String()),
+				ValidateFunc: validateDuration,
+			},
+			CACertFileField: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM-encoded CA bundle used to verify the RMON server certificate, in addition to the system roots.",
+				DefaultFunc: schema.EnvDefaultFunc("RMON_CA_CERT_FILE", nil),
+			},
+			CACertPEMField: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA certificates used to verify the RMON server certificate, in addition to the system roots.",
+			},
+			ClientCertField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "PEM-encoded client certificate, or a path to one, for mutual TLS.",
+				RequiredWith: []string{ClientKeyField},
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_CLIENT_CERT", nil),
+			},
+			ClientKeyField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "PEM-encoded private key for `client_cert`, or a path to one.",
+				RequiredWith: []string{ClientCertField},
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_CLIENT_KEY", nil),
+			},
+			TLSServerNameField: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Server name used to verify the RMON certificate when it differs from the host in `base_url`.",
+			},
+			InsecureSkipVerifyField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Disable verification of the RMON server certificate. Only use this for lab environments with self-signed certificates.",
+				DefaultFunc: schema.EnvDefaultFunc("RMON_INSECURE_SKIP_VERIFY", false),
+			},
+			ProxyURLField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the proxy used to reach RMON (`http`, `https` or `socks5`). When unset, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are honored.",
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+			},
+			CustomHeadersField: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Additional HTTP headers sent with every request to RMON, including the login request. The `Authorization` header cannot be overridden.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			MaxRequestsPerSecondField: {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Maximum number of requests per second sent to RMON by this provider instance, shared by all resources. Fractions are allowed (e.g. `0.5`). Set to 0 to disable the limit. Defaults to `0`.",
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_MAX_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			MaxConcurrentRequestsField: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of requests to RMON in flight at the same time, independent of Terraform's `-parallelism`. Set to 0 to disable the limit. Defaults to `0`.",
+				DefaultFunc:  schema.EnvDefaultFunc("RMON_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"rmon_group":             resourceGroup(),
@@ -59,8 +198,20 @@ func Provider() *schema.Provider {
 			"rmon_check_group":       resourceCheckGroup(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"rmon_group":     dataSourceGroup(),
-			"rmon_user_role": dataSourceUserRole(),
+			"rmon_group":        dataSourceGroup(),
+			"rmon_user_role":    dataSourceUserRole(),
+			"rmon_channel":      dataSourceChannel(),
+			"rmon_channels":     dataSourceChannels(),
+			"rmon_server":       dataSourceServer(),
+			"rmon_servers":      dataSourceServers(),
+			"rmon_agent":        dataSourceAgent(),
+			"rmon_agents":       dataSourceAgents(),
+			"rmon_region":       dataSourceRegion(),
+			"rmon_regions":      dataSourceRegions(),
+			"rmon_country":      dataSourceCountry(),
+			"rmon_countries":    dataSourceCountries(),
+			"rmon_checks":       dataSourceChecks(),
+			"rmon_check_status": dataSourceCheckStatus(),
 		},
 	}
 
@@ -76,26 +227,169 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(
-	_ context.Context,
+	ctx context.Context,
 	d *schema.ResourceData,
 	terraformVersion string,
 ) (interface{}, diag.Diagnostics) {
 	username := d.Get(LoginField).(string)
 	password := d.Get(PasswordField).(string)
+	apiToken := d.Get(APITokenField).(string)
 	apiEndpoint := d.Get(ProviderBaseURL).(string)
 
 	userAgent := fmt.Sprintf("terraform/%s", terraformVersion)
 
 	var diags diag.Diagnostics
 
-	client, err := NewClient(apiEndpoint, username, password, userAgent)
+	diags = append(diags, validateCredentials(username, password, apiToken)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	retry, err := expandRetryPolicy(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	requestTimeout, err := time.ParseDuration(d.Get(RequestTimeoutField).(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var proxyURL *url.URL
+	if v := d.Get(ProxyURLField).(string); v != "" {
+		proxyURL, err = url.Parse(v)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+
+	headers, err := expandCustomHeaders(d)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
 
+	client, err := rmonapi.NewClient(ctx, rmonapi.Config{
+		BaseURL:        apiEndpoint,
+		Login:          username,
+		Password:       password,
+		APIToken:       apiToken,
+		UserAgent:      userAgent,
+		TLS:            expandTLSConfig(d),
+		ProxyURL:       proxyURL,
+		Headers:        headers,
+		Retry:          retry,
+		RequestTimeout: requestTimeout,
+
+		MaxRequestsPerSecond:  d.Get(MaxRequestsPerSecondField).(float64),
+		MaxConcurrentRequests: d.Get(MaxConcurrentRequestsField).(int),
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if d.Get(InsecureSkipVerifyField).(bool) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS certificate verification is disabled",
+			Detail:   fmt.Sprintf("`%s` is set, so the identity of the RMON server is not verified.", InsecureSkipVerifyField),
+		})
+	}
+
+	serverVersion, err := detectServerVersion(ctx, client)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to detect RMON version, version-specific attributes will not be checked", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
 	config := &Config{
-		Client: client,
+		Client:        client,
+		ServerVersion: serverVersion,
 	}
 
 	return config, diags
 }
+
+// validateCredentials checks that exactly one authentication method is
+// configured: either login and password, or an API token.
+func validateCredentials(username, password, apiToken string) diag.Diagnostics {
+	hasLogin := username != "" || password != ""
+
+	switch {
+	case apiToken != "" && hasLogin:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Conflicting RMON credentials",
+			Detail: fmt.Sprintf("Both `%s` and `%s`/`%s` are set. Configure either an API token or a login and password, not both. "+
+				"Check the RMON_API_TOKEN, RMON_USERNAME and RMON_PASSWORD environment variables as well.", APITokenField, LoginField, PasswordField),
+		}}
+	case apiToken == "" && !hasLogin:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Missing RMON credentials",
+			Detail: fmt.Sprintf("Set `%s` (or RMON_API_TOKEN), or set both `%s` and `%s` (or RMON_USERNAME and RMON_PASSWORD).",
+				APITokenField, LoginField, PasswordField),
+		}}
+	case apiToken == "" && (username == "" || password == ""):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Incomplete RMON credentials",
+			Detail:   fmt.Sprintf("`%s` and `%s` must be set together.", LoginField, PasswordField),
+		}}
+	}
+
+	return nil
+}
+
+func expandTLSConfig(d *schema.ResourceData) rmonapi.TLSConfig {
+	return rmonapi.TLSConfig{
+		CACertFile:         d.Get(CACertFileField).(string),
+		CACertPEM:          d.Get(CACertPEMField).(string),
+		ClientCert:         d.Get(ClientCertField).(string),
+		ClientKey:          d.Get(ClientKeyField).(string),
+		ServerName:         d.Get(TLSServerNameField).(string),
+		InsecureSkipVerify: d.Get(InsecureSkipVerifyField).(bool),
+	}
+}
+
+func expandCustomHeaders(d *schema.ResourceData) (map[string]string, error) {
+	raw := d.Get(CustomHeadersField).(map[string]interface{})
+	headers := make(map[string]string, len(raw))
+
+	for name, value := range raw {
+		if strings.EqualFold(name, "Authorization") {
+			return nil, fmt.Errorf("`%s` must not contain the Authorization header, it is set by the provider", CustomHeadersField)
+		}
+		headers[name] = value.(string)
+	}
+
+	return headers, nil
+}
+
+func expandRetryPolicy(d *schema.ResourceData) (rmonapi.RetryPolicy, error) {
+	retry := rmonapi.DefaultRetryPolicy()
+	retry.MaxRetries = d.Get(MaxRetriesField).(int)
+
+	minBackoff, err := time.ParseDuration(d.Get(RetryMinBackoffField).(string))
+	if err != nil {
+		return retry, err
+	}
+	maxBackoff, err := time.ParseDuration(d.Get(RetryMaxBackoffField).(string))
+	if err != nil {
+		return retry, err
+	}
+	if minBackoff > maxBackoff {
+		return retry, fmt.Errorf("`%s` (%s) must not be greater than `%s` (%s)", RetryMinBackoffField, minBackoff, RetryMaxBackoffField, maxBackoff)
+	}
+	retry.MinBackoff = minBackoff
+	retry.MaxBackoff = maxBackoff
+
+	if codes := d.Get(RetryableStatusCodeField).([]interface{}); len(codes) > 0 {
+		retry.RetryableStatusCodes = make([]int, 0, len(codes))
+		for _, code := range codes {
+			retry.RetryableStatusCodes = append(retry.RetryableStatusCodes, code.(int))
+		}
+	}
+
+	return retry, nil
+}
@@ -0,0 +1,470 @@
+package rmon
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"terraform-provider-rmon/rmontest"
+)
+
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"rmon": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testAccServer starts a fake RMON API that is shut down with the test.
+func testAccServer(t *testing.T) *rmontest.Server {
+	t.Helper()
+
+	srv := rmontest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// testAccConfig prefixes config with a provider block pointing at srv.
+func testAccConfig(srv *rmontest.Server, config string) string {
+	return fmt.Sprintf(`
+provider "rmon" {
+  base_url    = %q
+  login       = %q
+  password    = %q
+  max_retries = 0
+}
+`, srv.URL, rmontest.DefaultLogin, rmontest.DefaultPassword) + config
+}
+
+// testAccCountRequests counts the requests received by srv since the first
+// from requests that match method and start with pathPrefix.
+func testAccCountRequests(srv *rmontest.Server, from int, method, pathPrefix string) int {
+	count := 0
+	for _, req := range srv.Requests()[from:] {
+		if req.Method == method && strings.HasPrefix(req.Path, pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func testAccResourceID(s *terraform.State, name string) (int, error) {
+	rs, ok := s.RootModule().Resources[name]
+	if !ok {
+		return 0, fmt.Errorf("resource %s not found in state", name)
+	}
+	return strconv.Atoi(rs.Primary.ID)
+}
+
+// testAccCheckExists verifies that the object behind the resource is stored
+// in the fake RMON.
+func testAccCheckExists(srv *rmontest.Server, kind, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, name)
+		if err != nil {
+			return err
+		}
+		if _, ok := srv.Get(kind, id); !ok {
+			return fmt.Errorf("%s %d does not exist in RMON", kind, id)
+		}
+		return nil
+	}
+}
+
+// testAccCheckDestroy verifies that no resource of the given type is left in
+// the fake RMON.
+func testAccCheckDestroy(srv *rmontest.Server, kind, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if _, ok := srv.Get(kind, id); ok {
+				return fmt.Errorf("%s %d still exists in RMON", kind, id)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccDeleteOutOfBand removes the object behind the resource without
+// Terraform knowing, so that the next plan has to recreate it.
+func testAccDeleteOutOfBand(srv *rmontest.Server, kind, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, name)
+		if err != nil {
+			return err
+		}
+		srv.Delete(kind, id)
+		return nil
+	}
+}
+
+// testAccUpdateOutOfBand changes the object behind the resource without
+// Terraform knowing, to simulate drift.
+func testAccUpdateOutOfBand(srv *rmontest.Server, kind, name string, fields rmontest.Object) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, name)
+		if err != nil {
+			return err
+		}
+		if !srv.Update(kind, id, fields) {
+			return fmt.Errorf("%s %d does not exist in RMON", kind, id)
+		}
+		return nil
+	}
+}
+
+// testAccCheckAttr verifies a field of the object stored in the fake RMON,
+// which catches attributes that are set in state but never sent.
+func testAccCheckAttr(srv *rmontest.Server, kind, name, field string, want interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, name)
+		if err != nil {
+			return err
+		}
+		obj, ok := srv.Get(kind, id)
+		if !ok {
+			return fmt.Errorf("%s %d does not exist in RMON", kind, id)
+		}
+		if got := fmt.Sprint(obj[field]); got != fmt.Sprint(want) {
+			return fmt.Errorf("%s %d: %s = %s, want %v", kind, id, field, got, want)
+		}
+		return nil
+	}
+}
+
+// testAccStoreID saves the resource ID so that a later step can tell whether
+// the resource was replaced.
+func testAccStoreID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckReplaced(name string, oldID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		if rs.Primary.ID == *oldID {
+			return fmt.Errorf("%s was updated in place, expected replacement (ID %s)", name, *oldID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckNotReplaced(name string, oldID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		if rs.Primary.ID != *oldID {
+			return fmt.Errorf("%s was replaced (ID %s -> %s), expected an update in place", name, *oldID, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func TestAccProvider_apiToken(t *testing.T) {
+	srv := testAccServer(t)
+	config := fmt.Sprintf(`
+provider "rmon" {
+  base_url    = %q
+  api_token   = %q
+  max_retries = 0
+}
+`, srv.URL, rmontest.DefaultAPIToken) + testAccResourceGroupConfig("web", "Web servers")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindGroup, "rmon_group.test"),
+					func(*terraform.State) error {
+						if logins := testAccCountRequests(srv, 0, "POST", "/api/v1.0/login"); logins != 0 {
+							return fmt.Errorf("provider logged in %d times, want 0 with api_token", logins)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_customHeaders(t *testing.T) {
+	srv := testAccServer(t)
+	config := fmt.Sprintf(`
+provider "rmon" {
+  base_url    = %q
+  login       = %q
+  password    = %q
+  max_retries = 0
+
+  custom_headers = {
+    "X-Tenant" = "acme"
+  }
+}
+`, srv.URL, rmontest.DefaultLogin, rmontest.DefaultPassword) + testAccResourceGroupConfig("web", "Web servers")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(*terraform.State) error {
+					var logins, groups int
+					for _, req := range srv.Requests() {
+						if got := req.Header.Get("X-Tenant"); got != "acme" {
+							return fmt.Errorf("%s %s: X-Tenant = %q, want acme", req.Method, req.Path, got)
+						}
+						switch {
+						case req.Path == "/api/v1.0/login":
+							logins++
+						case strings.HasPrefix(req.Path, "/api/v1.0/group"):
+							groups++
+						}
+					}
+					if logins == 0 || groups == 0 {
+						return fmt.Errorf("%d logins and %d group requests, want both", logins, groups)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProvider_customHeadersRejectAuthorization(t *testing.T) {
+	srv := testAccServer(t)
+	config := fmt.Sprintf(`
+provider "rmon" {
+  base_url    = %q
+  login       = %q
+  password    = %q
+  max_retries = 0
+
+  custom_headers = {
+    "authorization" = "Bearer other"
+  }
+}
+`, srv.URL, rmontest.DefaultLogin, rmontest.DefaultPassword) + testAccResourceGroupConfig("web", "Web servers")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("must not contain the Authorization header"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_proxyURL(t *testing.T) {
+	srv := testAccServer(t)
+
+	// A forward proxy: requests arrive with the absolute RMON URL, which the
+	// reverse proxy keeps as the target.
+	var mu sync.Mutex
+	var proxied []string
+	forward := &httputil.ReverseProxy{Director: func(*http.Request) {}}
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		proxied = append(proxied, r.URL.String())
+		mu.Unlock()
+		forward.ServeHTTP(w, r)
+	}))
+	t.Cleanup(proxy.Close)
+
+	config := fmt.Sprintf(`
+provider "rmon" {
+  base_url    = %q
+  login       = %q
+  password    = %q
+  proxy_url   = %q
+  max_retries = 0
+}
+`, srv.URL, rmontest.DefaultLogin, rmontest.DefaultPassword, proxy.URL) + testAccResourceGroupConfig("web", "Web servers")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(*terraform.State) error {
+					mu.Lock()
+					defer mu.Unlock()
+
+					if len(proxied) != len(srv.Requests()) {
+						return fmt.Errorf("%d requests went through the proxy, RMON received %d", len(proxied), len(srv.Requests()))
+					}
+					for _, target := range proxied {
+						if !strings.HasPrefix(target, srv.URL) {
+							return fmt.Errorf("proxy received a request for %s, want %s", target, srv.URL)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProvider_reauthenticatesOnExpiredToken(t *testing.T) {
+	srv := testAccServer(t)
+	var from int
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceGroupConfig("web", "Web servers")),
+			},
+			{
+				// Reject the first update as if the token had expired between
+				// the plan and the apply.
+				PreConfig: func() {
+					from = len(srv.Requests())
+					srv.InjectFault(rmontest.Fault{Method: "PUT", Path: "/api/v1.0/group/", StatusCode: http.StatusUnauthorized, Body: "Token has expired", Times: 1})
+				},
+				Config: testAccConfig(srv, testAccResourceGroupConfig("frontend", "Web servers")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, rmontest.KindGroup, "rmon_group.test", "name", "frontend"),
+					func(*terraform.State) error {
+						requests := srv.Requests()[from:]
+						for i, req := range requests {
+							if req.Method != "PUT" {
+								continue
+							}
+							// The rejected PUT must be followed by a login
+							// and then the replayed PUT.
+							if i+2 >= len(requests) || requests[i+1].Path != "/api/v1.0/login" || requests[i+2].Method != "PUT" {
+								return fmt.Errorf("rejected update was not followed by a login and a replay: %v", requests[i:])
+							}
+							return nil
+						}
+						return fmt.Errorf("no update was sent")
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_retriesServerErrors(t *testing.T) {
+	srv := testAccServer(t)
+	var from int
+	var start time.Time
+
+	config := fmt.Sprintf(`
+provider "rmon" {
+  base_url          = %q
+  login             = %q
+  password          = %q
+  max_retries       = 3
+  retry_min_backoff = "10ms"
+  retry_max_backoff = "2s"
+}
+`, srv.URL, rmontest.DefaultLogin, rmontest.DefaultPassword) + testAccResourceGroupConfig("web", "Web servers")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// The refresh has to wait out Retry-After twice.
+				PreConfig: func() {
+					from = len(srv.Requests())
+					start = time.Now()
+					srv.InjectFault(rmontest.Fault{Method: "GET", Path: "/api/v1.0/group/", StatusCode: http.StatusServiceUnavailable, RetryAfter: 1, Times: 2})
+				},
+				Config: config,
+				Check: func(*terraform.State) error {
+					if gets := testAccCountRequests(srv, from, "GET", "/api/v1.0/group/"); gets < 3 {
+						return fmt.Errorf("%d reads of the group, want at least 3", gets)
+					}
+					if elapsed := time.Since(start); elapsed < 2*time.Second {
+						return fmt.Errorf("step took %s, want at least the 2s from Retry-After", elapsed)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProvider_doesNotRetryCreateOnServerErrors(t *testing.T) {
+	srv := testAccServer(t)
+
+	config := fmt.Sprintf(`
+provider "rmon" {
+  base_url          = %q
+  login             = %q
+  password          = %q
+  max_retries       = 3
+  retry_min_backoff = "10ms"
+}
+`, srv.URL, rmontest.DefaultLogin, rmontest.DefaultPassword) + testAccResourceGroupConfig("web", "Web servers")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					srv.InjectFault(rmontest.Fault{Method: "POST", Path: "/api/v1.0/group", StatusCode: http.StatusBadGateway, Times: 1})
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile("unexpected status code: 502"),
+			},
+			{
+				PreConfig: func() {
+					// Only the Default group: the failed create was not
+					// retried behind the scenes.
+					if count := srv.Count(rmontest.KindGroup); count != 1 {
+						t.Fatalf("%d groups after the failed create, want 1", count)
+					}
+				},
+				Config: config,
+				Check:  testAccCheckExists(srv, rmontest.KindGroup, "rmon_group.test"),
+			},
+		},
+	})
+}
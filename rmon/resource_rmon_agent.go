@@ -2,14 +2,14 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -20,10 +20,10 @@ const (
 
 func resourceAgent() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceAgentCreate,
-		ReadWithoutTimeout:   resourceAgentRead,
-		UpdateWithoutTimeout: resourceAgentUpdate,
-		DeleteWithoutTimeout: resourceAgentDelete,
+		CreateContext: resourceAgentCreate,
+		ReadContext:   resourceAgentRead,
+		UpdateContext: resourceAgentUpdate,
+		DeleteContext: resourceAgentDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -81,92 +81,56 @@ func resourceAgent() *schema.Resource {
 func resourceAgentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	name := strings.ReplaceAll(d.Get(NameField).(string), "'", "")
-	shred := boolToInt(d.Get(SharedField).(bool))
-	enabled := boolToInt(d.Get(EnabledField).(bool))
-
-	server := map[string]interface{}{
-		SharedField:      shred,
-		DescriptionField: description,
-		EnabledField:     enabled,
-		NameField:        name,
-		ServerIdField:    d.Get(ServerIdField).(int),
-		PortField:        d.Get(PortField).(int),
-		RegionIdFiled:    d.Get(RegionIdFiled).(int),
-		ReconfigureField: true,
-	}
+	agent := expandAgent(d)
+	agent.Reconfigure = true
 
-	resp, err := client.doRequest("POST", "/api/v1.0/rmon/agent", server)
+	id, err := client.Agents.Create(ctx, agent)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	id, ok := result["id"].(float64)
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
-	}
-
-	d.SetId(fmt.Sprintf("%d", int(id)))
+	d.SetId(strconv.Itoa(id))
 	return resourceAgentRead(ctx, d, m)
 }
 
 func resourceAgentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/rmon/agent/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	agent, err := client.Agents.Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	description := strings.ReplaceAll(result[DescriptionField].(string), "'", "")
-	name := strings.ReplaceAll(result[NameField].(string), "'", "")
-	d.Set(DescriptionField, description)
-	d.Set(EnabledField, intToBool(result[EnabledField].(float64)))
-	d.Set(SharedField, intToBool(result[SharedField].(float64)))
-	d.Set(NameField, name)
-	d.Set(ServerIdField, result[ServerIdField])
-	d.Set(PortField, result[PortField])
-	d.Set(RegionIdFiled, result[RegionIdFiled])
+	d.Set(DescriptionField, strings.ReplaceAll(agent.Description, "'", ""))
+	d.Set(EnabledField, bool(agent.Enabled))
+	d.Set(SharedField, bool(agent.Shared))
+	d.Set(NameField, strings.ReplaceAll(agent.Name, "'", ""))
+	d.Set(ServerIdField, agent.ServerID)
+	d.Set(PortField, agent.Port)
+	d.Set(RegionIdFiled, agent.RegionID)
 
 	return nil
 }
 
 func resourceAgentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	name := strings.ReplaceAll(d.Get(NameField).(string), "'", "")
-
-	server := map[string]interface{}{
-		DescriptionField: description,
-		EnabledField:     boolToInt(d.Get(EnabledField).(bool)),
-		SharedField:      boolToInt(d.Get(SharedField).(bool)),
-		NameField:        name,
-		ServerIdField:    d.Get(ServerIdField).(int),
-		PortField:        d.Get(PortField).(int),
-		RegionIdFiled:    d.Get(RegionIdFiled).(int),
-		ReconfigureField: false,
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	if d.HasChange(PortField) {
-		server[ReconfigureField] = true
-	}
+	agent := expandAgent(d)
+	agent.Reconfigure = d.HasChange(PortField)
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/rmon/agent/%s", id), server)
-	if err != nil {
+	if err := client.Agents.Update(ctx, id, agent); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -175,13 +139,31 @@ func resourceAgentUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 func resourceAgentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/rmon/agent/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Agents.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func expandAgent(d *schema.ResourceData) *rmonapi.Agent {
+	return &rmonapi.Agent{
+		Name:        strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
+		Description: strings.ReplaceAll(d.Get(DescriptionField).(string), "'", ""),
+		Enabled:     rmonapi.Bool(d.Get(EnabledField).(bool)),
+		Shared:      rmonapi.Bool(d.Get(SharedField).(bool)),
+		ServerID:    d.Get(ServerIdField).(int),
+		Port:        d.Get(PortField).(int),
+		RegionID:    d.Get(RegionIdFiled).(int),
+	}
+}
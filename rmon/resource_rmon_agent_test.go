@@ -0,0 +1,72 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceAgent(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindAgent, "rmon_agent"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceAgentConfig("agent-1", 5101)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindAgent, "rmon_agent.test"),
+					testAccStoreID("rmon_agent.test", &id),
+					testAccCheckAttr(srv, rmontest.KindAgent, "rmon_agent.test", "reconfigure", true),
+					resource.TestCheckResourceAttr("rmon_agent.test", "name", "agent-1"),
+					resource.TestCheckResourceAttr("rmon_agent.test", "port", "5101"),
+					resource.TestCheckResourceAttr("rmon_agent.test", "shared", "true"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceAgentConfig("agent-2", 5101)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_agent.test", &id),
+					testAccCheckAttr(srv, rmontest.KindAgent, "rmon_agent.test", "reconfigure", false),
+					resource.TestCheckResourceAttr("rmon_agent.test", "name", "agent-2"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceAgentConfig("agent-2", 5102)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_agent.test", &id),
+					testAccCheckAttr(srv, rmontest.KindAgent, "rmon_agent.test", "reconfigure", true),
+					resource.TestCheckResourceAttr("rmon_agent.test", "port", "5102"),
+				),
+			},
+			{
+				ResourceName:      "rmon_agent.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceAgentConfig("agent-2", 5102)),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindAgent, "rmon_agent.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceAgentConfig(name string, port int) string {
+	return fmt.Sprintf(`
+resource "rmon_agent" "test" {
+  name        = %q
+  description = "test agent"
+  enabled     = true
+  shared      = true
+  server_id   = 1
+  port        = %d
+  region_id   = 1
+}
+`, name, port)
+}
@@ -2,15 +2,16 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"log"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -22,17 +23,70 @@ const (
 	ReceiverTypePagerDuty  = "pd"
 	ReceiverTypeMattermost = "mm"
 	ReceiverTypeEmail      = "email"
+	ReceiverTypeWebhook    = "webhook"
+	ReceiverTypeTeams      = "teams"
+	ReceiverTypeDiscord    = "discord"
+	ReceiverTypeOpsgenie   = "opsgenie"
+	WebhookURLField        = "webhook_url"
+	HMACSecretField        = "hmac_secret"
+	HeadersField           = "headers"
+	PayloadTemplateField   = "payload_template"
+	VerifyOnApplyField     = "verify_on_apply"
 )
 
+// channelReceiver lists the receiver-specific attributes a receiver type
+// requires or accepts; the others are rejected at plan time.
+type channelReceiver struct {
+	name     string
+	required []string
+	optional []string
+}
+
+var channelReceivers = []channelReceiver{
+	{name: ReceiverTypeTelegram, required: []string{TokenField}},
+	{name: ReceiverTypeSlack, required: []string{TokenField}},
+	{name: ReceiverTypePagerDuty, required: []string{TokenField}},
+	{name: ReceiverTypeMattermost, required: []string{TokenField}},
+	{name: ReceiverTypeEmail, required: []string{TokenField}},
+	{name: ReceiverTypeOpsgenie, required: []string{TokenField}},
+	{name: ReceiverTypeTeams, required: []string{WebhookURLField}},
+	{name: ReceiverTypeDiscord, required: []string{WebhookURLField}},
+	{
+		name:     ReceiverTypeWebhook,
+		required: []string{WebhookURLField},
+		optional: []string{HMACSecretField, HeadersField, PayloadTemplateField},
+	},
+}
+
+// channelReceiverFields are the attributes that depend on the receiver type.
+var channelReceiverFields = []string{TokenField, WebhookURLField, HMACSecretField, HeadersField, PayloadTemplateField}
+
+// channelDeliveryFields are the attributes that affect whether messages get
+// through, and therefore trigger a test message with verify_on_apply.
+var channelDeliveryFields = append([]string{
+	ChannelField,
+	TokenField + "_file",
+	TokenField + "_env",
+	VerifyOnApplyField,
+}, channelReceiverFields...)
+
+func channelReceiverNames() []string {
+	names := make([]string, 0, len(channelReceivers))
+	for _, receiver := range channelReceivers {
+		names = append(names, receiver.name)
+	}
+	return names
+}
+
 func resourceChannel() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceChannelCreate,
-		ReadWithoutTimeout:   resourceChannelRead,
-		UpdateWithoutTimeout: resourceChannelUpdate,
-		DeleteWithoutTimeout: resourceChannelDelete,
+		CreateContext: resourceChannelCreate,
+		ReadContext:   resourceChannelRead,
+		UpdateContext: resourceChannelUpdate,
+		DeleteContext: resourceChannelDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceChannelImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -41,15 +95,17 @@ func resourceChannel() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
-		Description: "Represents a communication channel such as Telegram, Slack, PagerDuty, Mattermost and/or Email.",
+		Description: "Represents a communication channel such as Telegram, Slack, PagerDuty, Mattermost, Email, Microsoft Teams, Discord, Opsgenie or a generic webhook.",
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: validateChannelReceiverFields,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			ReceiverField: {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				Description:  fmt.Sprintf("The type of the receiver. Only `%s`, `%s`, `%s`, `%s`, `%s` are allowed.", ReceiverTypeTelegram, ReceiverTypeSlack, ReceiverTypePagerDuty, ReceiverTypeMattermost, ReceiverTypeEmail),
-				ValidateFunc: validation.StringInSlice([]string{ReceiverTypeTelegram, ReceiverTypeSlack, ReceiverTypePagerDuty, ReceiverTypeMattermost, ReceiverTypeEmail}, true),
+				Description:  fmt.Sprintf("The type of the receiver. Only %s are allowed.", quoteList(channelReceiverNames())),
+				ValidateFunc: validation.StringInSlice(channelReceiverNames(), true),
 			},
 			ChannelField: {
 				Type:        schema.TypeString,
@@ -61,78 +117,106 @@ func resourceChannel() *schema.Resource {
 				Required:    true,
 				Description: "The ID of the group to which the channel belongs.",
 			},
-			TokenField: {
+			WebhookURLField: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				Description:  "The URL alerts are posted to. Required for the `webhook`, `teams` and `discord` receivers.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			HMACSecretField: {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The token used for the channel.",
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Secret used to sign `webhook` requests with HMAC-SHA256. RMON never returns it.",
 			},
-		},
+			HeadersField: {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Additional HTTP headers sent with `webhook` requests.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			PayloadTemplateField: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Template of the request body sent by the `webhook` receiver. RMON's default payload is used when unset.",
+			},
+			VerifyOnApplyField: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send a test message through the channel whenever it is created or its delivery settings change, and fail the apply if it cannot be delivered. A channel that fails the test on create is tainted; on update, the new settings are already saved in RMON when the apply fails.",
+			},
+		}, secretSchema(TokenField, "The token used for the channel. Required, directly or through `token_file` or `token_env`, for every receiver except `webhook`, `teams` and `discord`.", false)),
 	}
 }
 
 func resourceChannelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	receiver := d.Get(ReceiverField).(string)
-
-	channel := map[string]interface{}{
-		ReceiverField: receiver,
-		ChannelField:  strings.ReplaceAll(d.Get(ChannelField).(string), "'", ""),
-		GroupIDField:  d.Get(GroupIDField).(int),
-		TokenField:    d.Get(TokenField).(string),
-	}
-
-	resp, err := client.doRequest("POST", fmt.Sprintf("/api/v1.0/channel/%s", receiver), channel)
+	channel, err := expandChannel(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("API response: %s", resp)
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	id, err := client.Channels.Create(ctx, channel)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	id, ok := result["id"].(float64)
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
+	d.SetId(strconv.Itoa(id))
+
+	// The channel exists at this point, so a failed test leaves it tainted.
+	if d.Get(VerifyOnApplyField).(bool) {
+		if err := client.Channels.Test(ctx, channel.Receiver, id); err != nil {
+			return diag.Errorf("channel %d was created but the test message could not be delivered: %s", id, err)
+		}
 	}
 
-	d.SetId(fmt.Sprintf("%d", int(id)))
 	return resourceChannelRead(ctx, d, m)
 }
 
 func resourceChannelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-	receiver := d.Get(ReceiverField).(string)
-
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/channel/%s/%s", receiver, id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	receiver := d.Get(ReceiverField).(string)
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	channel, err := client.Channels.Get(ctx, receiver, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	if receiverValue, ok := result[ReceiverField].(string); ok && receiverValue != "" {
-		d.Set(ReceiverField, receiverValue)
+	if channel.Receiver != "" {
+		d.Set(ReceiverField, channel.Receiver)
 	}
 
-	if channelValue, ok := result[ChannelField].(string); ok && channelValue != "" {
-		channel := strings.ReplaceAll(channelValue, "'", "")
-		d.Set(ChannelField, channel)
+	if channel.Channel != "" {
+		d.Set(ChannelField, strings.ReplaceAll(channel.Channel, "'", ""))
 	}
 
-	if groupIDValue, ok := result[GroupIDField].(float64); ok {
-		d.Set(GroupIDField, int(groupIDValue))
-	}
+	d.Set(GroupIDField, channel.GroupID)
+
+	// RMON may only return a masked token, keep the configured one then.
+	flattenSecret(d, TokenField, channel.Token)
 
-	if tokenValue, ok := result[TokenField].(string); ok && tokenValue != "" {
-		d.Set(TokenField, tokenValue)
+	if !isRedacted(channel.WebhookURL) {
+		d.Set(WebhookURLField, channel.WebhookURL)
+	}
+	if len(channel.Headers) > 0 {
+		d.Set(HeadersField, channel.Headers)
+	}
+	if channel.PayloadTemplate != "" {
+		d.Set(PayloadTemplateField, channel.PayloadTemplate)
 	}
 
 	return nil
@@ -140,34 +224,127 @@ func resourceChannelRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 func resourceChannelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-	receiver := d.Get(ReceiverField).(string)
-
-	channel := map[string]interface{}{
-		ReceiverField: d.Get(ReceiverField).(string),
-		ChannelField:  strings.ReplaceAll(d.Get(ChannelField).(string), "'", ""),
-		GroupIDField:  d.Get(GroupIDField).(int),
-		TokenField:    d.Get(TokenField).(string),
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/channel/%s/%s", receiver, id), channel)
+	channel, err := expandChannel(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Channels.Update(ctx, id, channel); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get(VerifyOnApplyField).(bool) && d.HasChanges(channelDeliveryFields...) {
+		if err := client.Channels.Test(ctx, channel.Receiver, id); err != nil {
+			return diag.Errorf("channel %d was updated but the test message could not be delivered: %s", id, err)
+		}
+	}
+
 	return resourceChannelRead(ctx, d, m)
 }
 
 func resourceChannelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	receiver := d.Get(ReceiverField).(string)
 
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/channel/%s/%s", receiver, id), nil)
-	if err != nil {
+	if err := client.Channels.Delete(ctx, receiver, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
 	d.SetId("")
 	return nil
 }
+
+// resourceChannelImport accepts IDs of the form <receiver>/<id>, since the
+// receiver is part of every channel URL.
+func resourceChannelImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid ID format for channel: %q, expected <receiver>/<id>", d.Id())
+	}
+
+	receiver := strings.ToLower(parts[0])
+	if !slices.Contains(channelReceiverNames(), receiver) {
+		return nil, fmt.Errorf("invalid receiver %q in channel import ID, expected one of: %s",
+			parts[0], strings.Join(channelReceiverNames(), ", "))
+	}
+
+	d.Set(ReceiverField, receiver)
+	d.Set(VerifyOnApplyField, false)
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func validateChannelReceiverFields(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	name := d.Get(ReceiverField).(string)
+
+	var receiver *channelReceiver
+	for i := range channelReceivers {
+		if strings.EqualFold(channelReceivers[i].name, name) {
+			receiver = &channelReceivers[i]
+		}
+	}
+	if receiver == nil {
+		return nil
+	}
+
+	for _, field := range channelReceiverFields {
+		// Values computed from other resources are only checked at apply.
+		if !d.NewValueKnown(field) {
+			continue
+		}
+		_, set := d.GetOk(field)
+		if field == TokenField {
+			set = secretIsSet(d, field)
+		}
+
+		switch {
+		case containsString(receiver.required, field):
+			if !set {
+				return fmt.Errorf("`%s` is required for the %s receiver", field, receiver.name)
+			}
+		case containsString(receiver.optional, field):
+		default:
+			if set {
+				return fmt.Errorf("`%s` is not supported by the %s receiver", field, receiver.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func expandChannel(d *schema.ResourceData) (*rmonapi.Channel, error) {
+	token, err := expandSecret(d, TokenField)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(map[string]string)
+	for key, value := range d.Get(HeadersField).(map[string]interface{}) {
+		headers[key] = value.(string)
+	}
+
+	return &rmonapi.Channel{
+		Receiver:        d.Get(ReceiverField).(string),
+		Channel:         strings.ReplaceAll(d.Get(ChannelField).(string), "'", ""),
+		GroupID:         d.Get(GroupIDField).(int),
+		Token:           token,
+		WebhookURL:      d.Get(WebhookURLField).(string),
+		HMACSecret:      d.Get(HMACSecretField).(string),
+		Headers:         headers,
+		PayloadTemplate: d.Get(PayloadTemplateField).(string),
+	}, nil
+}
@@ -0,0 +1,374 @@
+package rmon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceChannel(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceChannelConfig("telegram", "alerts")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "channel/telegram", "rmon_channel.test"),
+					testAccStoreID("rmon_channel.test", &id),
+					resource.TestCheckResourceAttr("rmon_channel.test", "receiver", "telegram"),
+					resource.TestCheckResourceAttr("rmon_channel.test", "channel", "alerts"),
+					resource.TestCheckResourceAttr("rmon_channel.test", "token", "bot-token"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceChannelConfig("telegram", "critical")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_channel.test", &id),
+					testAccCheckAttr(srv, "channel/telegram", "rmon_channel.test", "channel", "critical"),
+					resource.TestCheckResourceAttr("rmon_channel.test", "channel", "critical"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceChannelConfig("slack", "critical")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "channel/slack", "rmon_channel.test"),
+					resource.TestCheckResourceAttr("rmon_channel.test", "receiver", "slack"),
+					func(s *terraform.State) error {
+						if srv.Count("channel/telegram") != 0 {
+							return fmt.Errorf("telegram channel was not deleted when the receiver changed")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "rmon_channel.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccChannelImportID("rmon_channel.test"),
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceChannelConfig("slack", "critical")),
+				Check:              testAccDeleteOutOfBand(srv, "channel/slack", "rmon_channel.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceChannel_invalidImportID(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:        testAccConfig(srv, testAccResourceChannelConfig("telegram", "alerts")),
+				ResourceName:  "rmon_channel.test",
+				ImportState:   true,
+				ImportStateId: "1",
+				ExpectError:   regexp.MustCompile(`expected <receiver>/<id>`),
+			},
+			{
+				Config:        testAccConfig(srv, testAccResourceChannelConfig("telegram", "alerts")),
+				ResourceName:  "rmon_channel.test",
+				ImportState:   true,
+				ImportStateId: "telegarm/1",
+				ExpectError:   regexp.MustCompile(`invalid receiver "telegarm"`),
+			},
+		},
+	})
+}
+
+func TestAccResourceChannel_webhook(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_channel" "test" {
+  receiver         = "webhook"
+  channel          = "on-call"
+  group_id         = 1
+  webhook_url      = "https://hooks.example.com/rmon"
+  hmac_secret      = "signing-secret"
+  payload_template = "{\"text\": \"{{ .Message }}\"}"
+
+  headers = {
+    "X-Team" = "sre"
+  }
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "channel/webhook", "rmon_channel.test"),
+					testAccCheckAttr(srv, "channel/webhook", "rmon_channel.test", "webhook_url", "https://hooks.example.com/rmon"),
+					testAccCheckAttr(srv, "channel/webhook", "rmon_channel.test", "hmac_secret", "signing-secret"),
+					testAccCheckAttr(srv, "channel/webhook", "rmon_channel.test", "headers", map[string]interface{}{"X-Team": "sre"}),
+					resource.TestCheckResourceAttr("rmon_channel.test", "headers.X-Team", "sre"),
+					resource.TestCheckResourceAttr("rmon_channel.test", "payload_template", `{"text": "{{ .Message }}"}`),
+				),
+			},
+			{
+				ResourceName:      "rmon_channel.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccChannelImportID("rmon_channel.test"),
+				ImportStateVerify: true,
+				// RMON never returns the HMAC secret.
+				ImportStateVerifyIgnore: []string{HMACSecretField},
+			},
+		},
+	})
+}
+
+func TestAccResourceChannel_receiverFields(t *testing.T) {
+	srv := testAccServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_channel" "test" {
+  receiver = "telegram"
+  channel  = "alerts"
+  group_id = 1
+}
+`),
+				ExpectError: regexp.MustCompile("`token` is required for the telegram receiver"),
+			},
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_channel" "test" {
+  receiver    = "opsgenie"
+  channel     = "alerts"
+  group_id    = 1
+  token       = "api-key"
+  hmac_secret = "signing-secret"
+}
+`),
+				ExpectError: regexp.MustCompile("`hmac_secret` is not supported by the opsgenie receiver"),
+			},
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_channel" "test" {
+  receiver = "teams"
+  channel  = "alerts"
+  group_id = 1
+}
+`),
+				ExpectError: regexp.MustCompile("`webhook_url` is required for the teams receiver"),
+			},
+		},
+	})
+}
+
+func TestAccResourceChannel_tokenSources(t *testing.T) {
+	srv := testAccServer(t)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RMON_TEST_CHANNEL_TOKEN", "env-token")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, fmt.Sprintf(`
+resource "rmon_channel" "test" {
+  receiver   = "slack"
+  channel    = "alerts"
+  group_id   = 1
+  token_file = %q
+}
+`, tokenFile)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "channel/slack", "rmon_channel.test", "token", "file-token"),
+					resource.TestCheckNoResourceAttr("rmon_channel.test", "token"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_channel" "test" {
+  receiver  = "slack"
+  channel   = "alerts"
+  group_id  = 1
+  token_env = "RMON_TEST_CHANNEL_TOKEN"
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "channel/slack", "rmon_channel.test", "token", "env-token"),
+					resource.TestCheckNoResourceAttr("rmon_channel.test", "token"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, `
+resource "rmon_channel" "test" {
+  receiver  = "slack"
+  channel   = "alerts"
+  group_id  = 1
+  token_env = "RMON_TEST_CHANNEL_TOKEN_MISSING"
+}
+`),
+				ExpectError: regexp.MustCompile(`environment variable RMON_TEST_CHANNEL_TOKEN_MISSING set in .token_env. is empty`),
+			},
+		},
+	})
+}
+
+func TestAccResourceChannel_redactedToken(t *testing.T) {
+	srv := testAccServer(t)
+	config := testAccConfig(srv, testAccResourceChannelConfig("telegram", "alerts"))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("rmon_channel.test", "token", "bot-token"),
+			},
+			{
+				// A masked token must not cause a diff.
+				Config: config,
+				Check:  testAccUpdateOutOfBand(srv, "channel/telegram", "rmon_channel.test", rmontest.Object{"token": "bot-****"}),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// A different token is real drift.
+				Config:             config,
+				Check:              testAccUpdateOutOfBand(srv, "channel/telegram", "rmon_channel.test", rmontest.Object{"token": "rotated-token"}),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceChannel_verifyOnApply(t *testing.T) {
+	srv := testAccServer(t)
+	testPath := "/api/v1.0/channel/telegram/1/test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckChannelDestroy(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceChannelVerifyConfig("alerts", "1")),
+				Check:  testAccCheckChannelTests(srv, testPath, 1),
+			},
+			{
+				PreConfig: func() {
+					srv.InjectFault(rmontest.Fault{
+						Method:     "POST",
+						Path:       testPath,
+						StatusCode: 400,
+						Body:       "Bad Request: chat not found",
+					})
+				},
+				Config:      testAccConfig(srv, testAccResourceChannelVerifyConfig("missing", "1")),
+				ExpectError: regexp.MustCompile(`test message could not be delivered: .*chat not found`),
+			},
+			{
+				// Changes that do not affect delivery are not tested.
+				PreConfig: srv.ClearFaults,
+				Config:    testAccConfig(srv, testAccResourceChannelVerifyConfig("missing", "rmon_group.other.id")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckChannelTests(srv, testPath, 2),
+					resource.TestCheckResourceAttrPair("rmon_channel.test", "group_id", "rmon_group.other", "id"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceChannelVerifyConfig("critical", "rmon_group.other.id")),
+				Check:  testAccCheckChannelTests(srv, testPath, 3),
+			},
+		},
+	})
+}
+
+func testAccCheckChannelTests(srv *rmontest.Server, path string, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var got int
+		for _, request := range srv.Requests() {
+			if request.Method == "POST" && request.Path == path {
+				got++
+			}
+		}
+		if got != want {
+			return fmt.Errorf("%d test messages were sent, want %d", got, want)
+		}
+		return nil
+	}
+}
+
+func testAccResourceChannelVerifyConfig(channel, groupID string) string {
+	return fmt.Sprintf(`
+resource "rmon_group" "other" {
+  name = "other"
+}
+
+resource "rmon_channel" "test" {
+  receiver        = "telegram"
+  channel         = %q
+  group_id        = %s
+  token           = "bot-token"
+  verify_on_apply = true
+}
+`, channel, groupID)
+}
+
+func testAccChannelImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes[ReceiverField], rs.Primary.ID), nil
+	}
+}
+
+func testAccCheckChannelDestroy(srv *rmontest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "rmon_channel" {
+				continue
+			}
+			id, err := strconv.Atoi(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if _, ok := srv.Get("channel/"+rs.Primary.Attributes[ReceiverField], id); ok {
+				return fmt.Errorf("channel %d still exists in RMON", id)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccResourceChannelConfig(receiver, channel string) string {
+	return fmt.Sprintf(`
+resource "rmon_channel" "test" {
+  receiver = %q
+  channel  = %q
+  group_id = 1
+  token    = "bot-token"
+}
+`, receiver, channel)
+}
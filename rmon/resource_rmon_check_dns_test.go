@@ -0,0 +1,67 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCheckDns(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/dns", "rmon_check_dns"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckDnsConfig("dns check", 60)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/dns", "rmon_check_dns.test"),
+					testAccStoreID("rmon_check_dns.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_dns.test", "name", "dns check"),
+					resource.TestCheckResourceAttr("rmon_check_dns.test", "interval", "60"),
+					resource.TestCheckResourceAttr("rmon_check_dns.test", "resolver", "1.1.1.1"),
+					resource.TestCheckResourceAttr("rmon_check_dns.test", "record_type", "a"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckDnsConfig("dns check renamed", 120)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_dns.test", &id),
+					testAccCheckAttr(srv, "rmon/check/dns", "rmon_check_dns.test", "interval", 120),
+					resource.TestCheckResourceAttr("rmon_check_dns.test", "name", "dns check renamed"),
+					resource.TestCheckResourceAttr("rmon_check_dns.test", "interval", "120"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_dns.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckDnsConfig("dns check renamed", 120)),
+				Check:              testAccDeleteOutOfBand(srv, "rmon/check/dns", "rmon_check_dns.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCheckDnsConfig(name string, interval int) string {
+	return fmt.Sprintf(`
+resource "rmon_check_dns" "test" {
+  name     = %q
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  interval = %d
+
+  ip          = "example.com"
+  port        = 53
+  resolver    = "1.1.1.1"
+  record_type = "a"
+}
+`, name, interval)
+}
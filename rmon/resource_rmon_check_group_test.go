@@ -0,0 +1,56 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceCheckGroup(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindCheckGroup, "rmon_check_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckGroupConfig("web checks")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindCheckGroup, "rmon_check_group.test"),
+					testAccStoreID("rmon_check_group.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_group.test", "name", "web checks"),
+					resource.TestCheckResourceAttr("rmon_check_group.test", "group_id", "1"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckGroupConfig("frontend checks")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_group.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_group.test", "name", "frontend checks"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckGroupConfig("frontend checks")),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindCheckGroup, "rmon_check_group.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCheckGroupConfig(name string) string {
+	return fmt.Sprintf(`
+resource "rmon_check_group" "test" {
+  name     = %q
+  group_id = 1
+}
+`, name)
+}
@@ -0,0 +1,168 @@
+package rmon
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceCheckHttp(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/http", "rmon_check_http"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckHttpConfig("http check", 60, "https://example.com/health")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/http", "rmon_check_http.test"),
+					testAccStoreID("rmon_check_http.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "name", "http check"),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "interval", "60"),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "url", "https://example.com/health"),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "method", "get"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckHttpConfig("http check renamed", 120, "https://example.com/health")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_http.test", &id),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "interval", 120),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "reconfigure", nil),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "name", "http check renamed"),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "interval", "120"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckHttpConfig("http check renamed", 120, "https://example.com/status")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_http.test", &id),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "reconfigure", true),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "url", "https://example.com/status"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_http.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckHttpConfig("http check renamed", 120, "https://example.com/status")),
+				Check:              testAccDeleteOutOfBand(srv, "rmon/check/http", "rmon_check_http.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceCheckHttp_versionGate(t *testing.T) {
+	srv := testAccServer(t)
+	srv.SetVersion("1.1.0")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/http", "rmon_check_http"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfig(srv, testAccResourceCheckHttpRedirectsConfig(3)),
+				ExpectError: regexp.MustCompile(`redirects.+requires RMON\s+>=\s+1\.2\.0`),
+			},
+			{
+				// Without the attribute in the configuration, its default is
+				// not sent to a server that would reject it.
+				Config: testAccConfig(srv, testAccResourceCheckHttpConfig("http check", 60, "https://example.com/health")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/http", "rmon_check_http.test"),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "redirects", nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCheckHttp_preVersionEndpoint(t *testing.T) {
+	srv := testAccServer(t)
+	// RMON releases before the /version endpoint answer it with a 404, and
+	// know none of the gated attributes.
+	srv.SetVersion("")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/http", "rmon_check_http"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccConfig(srv, testAccResourceCheckHttpRedirectsConfig(3)),
+				ExpectError: regexp.MustCompile(`redirects.+requires RMON\s+>=\s+1\.2\.0,\s+but\s+the\s+server\s+predates`),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckHttpConfig("http check", 60, "https://example.com/health")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/http", "rmon_check_http.test"),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "retries", nil),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "runbook", nil),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "redirects", nil),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "notifications", nil),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "email_channel_id", nil),
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "opsgenie_channel_id", nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceCheckHttp_undetectedVersion(t *testing.T) {
+	srv := testAccServer(t)
+	// A transient error leaves the version unknown, which must not block
+	// attributes the server most likely supports.
+	srv.InjectFault(rmontest.Fault{Method: "GET", Path: "/api/v1.0/version", StatusCode: http.StatusServiceUnavailable})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/http", "rmon_check_http"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckHttpRedirectsConfig(3)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/http", "rmon_check_http.test", "redirects", 3),
+					resource.TestCheckResourceAttr("rmon_check_http.test", "redirects", "3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceCheckHttpConfig(name string, interval int, url string) string {
+	return fmt.Sprintf(`
+resource "rmon_check_http" "test" {
+  name     = %q
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  interval = %d
+
+  url    = %q
+  method = "get"
+}
+`, name, interval, url)
+}
+
+func testAccResourceCheckHttpRedirectsConfig(redirects int) string {
+	return fmt.Sprintf(`
+resource "rmon_check_http" "test" {
+  name     = "http check"
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+
+  url       = "https://example.com/health"
+  method    = "get"
+  redirects = %d
+}
+`, redirects)
+}
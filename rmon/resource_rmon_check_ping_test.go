@@ -0,0 +1,74 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCheckPing(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/ping", "rmon_check_ping"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckPingConfig("ping check", 60, "10.0.0.1")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/ping", "rmon_check_ping.test"),
+					testAccStoreID("rmon_check_ping.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "name", "ping check"),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "interval", "60"),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "packet_size", "56"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckPingConfig("ping check renamed", 120, "10.0.0.1")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_ping.test", &id),
+					testAccCheckAttr(srv, "rmon/check/ping", "rmon_check_ping.test", "interval", 120),
+					testAccCheckAttr(srv, "rmon/check/ping", "rmon_check_ping.test", "reconfigure", nil),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "name", "ping check renamed"),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "interval", "120"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckPingConfig("ping check renamed", 120, "10.0.0.2")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_ping.test", &id),
+					testAccCheckAttr(srv, "rmon/check/ping", "rmon_check_ping.test", "reconfigure", true),
+					resource.TestCheckResourceAttr("rmon_check_ping.test", "ip", "10.0.0.2"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_ping.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckPingConfig("ping check renamed", 120, "10.0.0.2")),
+				Check:              testAccDeleteOutOfBand(srv, "rmon/check/ping", "rmon_check_ping.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCheckPingConfig(name string, interval int, ip string) string {
+	return fmt.Sprintf(`
+resource "rmon_check_ping" "test" {
+  name     = %q
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  interval = %d
+
+  ip          = %q
+  packet_size = 56
+}
+`, name, interval, ip)
+}
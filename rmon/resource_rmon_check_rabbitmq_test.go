@@ -0,0 +1,68 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCheckRabbitmq(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/rabbitmq", "rmon_check_rabbitmq"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckRabbitmqConfig("rabbitmq check", 60)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/rabbitmq", "rmon_check_rabbitmq.test"),
+					testAccStoreID("rmon_check_rabbitmq.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_rabbitmq.test", "name", "rabbitmq check"),
+					resource.TestCheckResourceAttr("rmon_check_rabbitmq.test", "interval", "60"),
+					resource.TestCheckResourceAttr("rmon_check_rabbitmq.test", "vhost", "/"),
+					resource.TestCheckResourceAttr("rmon_check_rabbitmq.test", "username", "monitor"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckRabbitmqConfig("rabbitmq check renamed", 120)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_rabbitmq.test", &id),
+					testAccCheckAttr(srv, "rmon/check/rabbitmq", "rmon_check_rabbitmq.test", "interval", 120),
+					resource.TestCheckResourceAttr("rmon_check_rabbitmq.test", "name", "rabbitmq check renamed"),
+					resource.TestCheckResourceAttr("rmon_check_rabbitmq.test", "interval", "120"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_rabbitmq.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckRabbitmqConfig("rabbitmq check renamed", 120)),
+				Check:              testAccDeleteOutOfBand(srv, "rmon/check/rabbitmq", "rmon_check_rabbitmq.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCheckRabbitmqConfig(name string, interval int) string {
+	return fmt.Sprintf(`
+resource "rmon_check_rabbitmq" "test" {
+  name     = %q
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  interval = %d
+
+  ip       = "10.0.0.1"
+  port     = 5672
+  username = "monitor"
+  password = "s3cret"
+  vhost    = "/"
+}
+`, name, interval)
+}
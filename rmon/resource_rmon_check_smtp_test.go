@@ -0,0 +1,111 @@
+package rmon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceCheckSmtp(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/smtp", "rmon_check_smtp"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckSmtpConfig("smtp check", 60)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/smtp", "rmon_check_smtp.test"),
+					testAccStoreID("rmon_check_smtp.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_smtp.test", "name", "smtp check"),
+					resource.TestCheckResourceAttr("rmon_check_smtp.test", "interval", "60"),
+					resource.TestCheckResourceAttr("rmon_check_smtp.test", "ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("rmon_check_smtp.test", "username", "monitor"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckSmtpConfig("smtp check renamed", 120)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_smtp.test", &id),
+					testAccCheckAttr(srv, "rmon/check/smtp", "rmon_check_smtp.test", "interval", 120),
+					resource.TestCheckResourceAttr("rmon_check_smtp.test", "name", "smtp check renamed"),
+					resource.TestCheckResourceAttr("rmon_check_smtp.test", "interval", "120"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_smtp.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckSmtpConfig("smtp check renamed", 120)),
+				Check:              testAccDeleteOutOfBand(srv, "rmon/check/smtp", "rmon_check_smtp.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceCheckSmtp_passwordFile(t *testing.T) {
+	srv := testAccServer(t)
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("file-s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := testAccConfig(srv, fmt.Sprintf(`
+resource "rmon_check_smtp" "test" {
+  name     = "smtp check"
+  place    = "agent"
+  entities = [1]
+
+  ip            = "10.0.0.1"
+  username      = "monitor"
+  password_file = %q
+}
+`, passwordFile))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/smtp", "rmon_check_smtp"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAttr(srv, "rmon/check/smtp", "rmon_check_smtp.test", "password", "file-s3cret"),
+					resource.TestCheckNoResourceAttr("rmon_check_smtp.test", "password"),
+				),
+			},
+			{
+				Config: config,
+				Check:  testAccUpdateOutOfBand(srv, "rmon/check/smtp", "rmon_check_smtp.test", rmontest.Object{"password": "******"}),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCheckSmtpConfig(name string, interval int) string {
+	return fmt.Sprintf(`
+resource "rmon_check_smtp" "test" {
+  name     = %q
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  interval = %d
+
+  ip       = "10.0.0.1"
+  port     = 587
+  username = "monitor"
+  password = "s3cret"
+}
+`, name, interval)
+}
@@ -0,0 +1,65 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCheckTcp(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, "rmon/check/tcp", "rmon_check_tcp"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCheckTcpConfig("tcp check", 60)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, "rmon/check/tcp", "rmon_check_tcp.test"),
+					testAccStoreID("rmon_check_tcp.test", &id),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "name", "tcp check"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "interval", "60"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "port", "443"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCheckTcpConfig("tcp check renamed", 120)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_check_tcp.test", &id),
+					testAccCheckAttr(srv, "rmon/check/tcp", "rmon_check_tcp.test", "interval", 120),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "name", "tcp check renamed"),
+					resource.TestCheckResourceAttr("rmon_check_tcp.test", "interval", "120"),
+				),
+			},
+			{
+				ResourceName:      "rmon_check_tcp.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCheckTcpConfig("tcp check renamed", 120)),
+				Check:              testAccDeleteOutOfBand(srv, "rmon/check/tcp", "rmon_check_tcp.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCheckTcpConfig(name string, interval int) string {
+	return fmt.Sprintf(`
+resource "rmon_check_tcp" "test" {
+  name     = %q
+  enabled  = true
+  place    = "agent"
+  entities = [1]
+  interval = %d
+
+  ip   = "10.0.0.1"
+  port = 443
+}
+`, name, interval)
+}
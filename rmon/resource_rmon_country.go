@@ -2,21 +2,21 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 func resourceCountry() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceCountryCreate,
-		ReadWithoutTimeout:   resourceCountryRead,
-		UpdateWithoutTimeout: resourceCountryUpdate,
-		DeleteWithoutTimeout: resourceCountryDelete,
+		CreateContext: resourceCountryCreate,
+		ReadContext:   resourceCountryRead,
+		UpdateContext: resourceCountryUpdate,
+		DeleteContext: resourceCountryDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -63,82 +63,48 @@ func resourceCountry() *schema.Resource {
 func resourceCountryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	name := strings.ReplaceAll(d.Get(NameField).(string), "'", "")
-	shred := boolToInt(d.Get(SharedField).(bool))
-	enabled := boolToInt(d.Get(EnabledField).(bool))
-
-	server := map[string]interface{}{
-		SharedField:      shred,
-		DescriptionField: description,
-		EnabledField:     enabled,
-		NameField:        name,
-		GroupIDField:     d.Get(GroupIDField).(int),
-	}
-
-	resp, err := client.doRequest("POST", "/api/v1.0/rmon/country", server)
+	id, err := client.Countries.Create(ctx, expandCountry(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	id, ok := result["id"].(float64)
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
-	}
-
-	d.SetId(fmt.Sprintf("%d", int(id)))
+	d.SetId(strconv.Itoa(id))
 	return resourceCountryRead(ctx, d, m)
 }
 
 func resourceCountryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/rmon/country/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	country, err := client.Countries.Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	description := strings.ReplaceAll(result[DescriptionField].(string), "'", "")
-	name := strings.ReplaceAll(result[NameField].(string), "'", "")
-	d.Set(DescriptionField, description)
-	d.Set(EnabledField, result[EnabledField])
-	d.Set(SharedField, result[SharedField])
-	d.Set(NameField, name)
-	d.Set(GroupIDField, result[GroupIDField])
+	d.Set(DescriptionField, strings.ReplaceAll(country.Description, "'", ""))
+	d.Set(EnabledField, bool(country.Enabled))
+	d.Set(SharedField, bool(country.Shared))
+	d.Set(NameField, strings.ReplaceAll(country.Name, "'", ""))
+	d.Set(GroupIDField, country.GroupID)
 
 	return nil
 }
 
 func resourceCountryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	name := strings.ReplaceAll(d.Get(NameField).(string), "'", "")
-	shred := boolToInt(d.Get(SharedField).(bool))
-	enabled := boolToInt(d.Get(EnabledField).(bool))
-
-	server := map[string]interface{}{
-		SharedField:      shred,
-		DescriptionField: description,
-		EnabledField:     enabled,
-		NameField:        name,
-		GroupIDField:     d.Get(GroupIDField).(int),
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/rmon/country/%s", id), server)
-	if err != nil {
+	if err := client.Countries.Update(ctx, id, expandCountry(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -147,13 +113,29 @@ func resourceCountryUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 func resourceCountryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/rmon/country/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Countries.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func expandCountry(d *schema.ResourceData) *rmonapi.Country {
+	return &rmonapi.Country{
+		Name:        strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
+		Description: strings.ReplaceAll(d.Get(DescriptionField).(string), "'", ""),
+		Enabled:     rmonapi.Bool(d.Get(EnabledField).(bool)),
+		Shared:      rmonapi.Bool(d.Get(SharedField).(bool)),
+		GroupID:     d.Get(GroupIDField).(int),
+	}
+}
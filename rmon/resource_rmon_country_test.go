@@ -0,0 +1,61 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceCountry(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindCountry, "rmon_country"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceCountryConfig("Germany", false)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindCountry, "rmon_country.test"),
+					testAccStoreID("rmon_country.test", &id),
+					resource.TestCheckResourceAttr("rmon_country.test", "name", "Germany"),
+					resource.TestCheckResourceAttr("rmon_country.test", "shared", "false"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceCountryConfig("Deutschland", true)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_country.test", &id),
+					testAccCheckAttr(srv, rmontest.KindCountry, "rmon_country.test", "shared", 1),
+					resource.TestCheckResourceAttr("rmon_country.test", "name", "Deutschland"),
+					resource.TestCheckResourceAttr("rmon_country.test", "shared", "true"),
+				),
+			},
+			{
+				ResourceName:      "rmon_country.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceCountryConfig("Deutschland", true)),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindCountry, "rmon_country.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceCountryConfig(name string, shared bool) string {
+	return fmt.Sprintf(`
+resource "rmon_country" "test" {
+  name        = %q
+  description = "test country"
+  enabled     = true
+  shared      = %t
+  group_id    = 1
+}
+`, name, shared)
+}
@@ -2,21 +2,20 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 func resourceGroup() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceGroupCreate,
-		ReadWithoutTimeout:   resourceGroupRead,
-		UpdateWithoutTimeout: resourceGroupUpdate,
-		DeleteWithoutTimeout: resourceGroupDelete,
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -47,70 +46,46 @@ func resourceGroup() *schema.Resource {
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	name := d.Get(NameField).(string)
-	description := d.Get(DescriptionField).(string)
 
-	requestBody := map[string]string{NameField: name, DescriptionField: description}
-	resp, err := client.doRequest("POST", "/api/v1.0/group", requestBody)
+	id, err := client.Groups.Create(ctx, expandGroup(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("API response: %s", resp)
-
-	// Assuming the response contains an ID field with the unique identifier
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	id, ok := result[IDField].(float64) // ID возвращается как число
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
-	}
-
-	d.SetId(fmt.Sprintf("%d", int(id))) // Преобразование ID в строку
+	d.SetId(strconv.Itoa(id))
 	return resourceGroupRead(ctx, d, m)
 }
 
 func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	// Implement API call to read the resource
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/group/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	// Process response and set data
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	group, err := client.Groups.Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	if name, ok := result[NameField].(string); ok {
-		d.Set(NameField, name)
-	}
-
-	if description, ok := result[DescriptionField].(string); ok {
-		d.Set(DescriptionField, description)
-	}
+	d.Set(NameField, group.Name)
+	d.Set(DescriptionField, group.Description)
 
 	return nil
 }
 
 func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	requestBody := map[string]string{NameField: d.Get(NameField).(string)}
-	if d.HasChange(DescriptionField) {
-		requestBody[DescriptionField] = d.Get(DescriptionField).(string)
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/group/%s", id), requestBody)
-	if err != nil {
+	if err := client.Groups.Update(ctx, id, expandGroup(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -119,14 +94,26 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	// Implement API call to delete the resource
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/group/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Groups.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func expandGroup(d *schema.ResourceData) *rmonapi.Group {
+	return &rmonapi.Group{
+		Name:        d.Get(NameField).(string),
+		Description: d.Get(DescriptionField).(string),
+	}
+}
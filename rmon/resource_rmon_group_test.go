@@ -0,0 +1,58 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceGroup(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindGroup, "rmon_group"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceGroupConfig("web", "Web servers")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindGroup, "rmon_group.test"),
+					testAccStoreID("rmon_group.test", &id),
+					resource.TestCheckResourceAttr("rmon_group.test", "name", "web"),
+					resource.TestCheckResourceAttr("rmon_group.test", "description", "Web servers"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceGroupConfig("frontend", "")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_group.test", &id),
+					testAccCheckAttr(srv, rmontest.KindGroup, "rmon_group.test", "name", "frontend"),
+					resource.TestCheckResourceAttr("rmon_group.test", "name", "frontend"),
+					resource.TestCheckResourceAttr("rmon_group.test", "description", ""),
+				),
+			},
+			{
+				ResourceName:      "rmon_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceGroupConfig("frontend", "")),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindGroup, "rmon_group.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceGroupConfig(name, description string) string {
+	return fmt.Sprintf(`
+resource "rmon_group" "test" {
+  name        = %q
+  description = %q
+}
+`, name, description)
+}
@@ -2,13 +2,13 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -17,10 +17,10 @@ const (
 
 func resourceRegion() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceRegionCreate,
-		ReadWithoutTimeout:   resourceRegionRead,
-		UpdateWithoutTimeout: resourceRegionUpdate,
-		DeleteWithoutTimeout: resourceRegionDelete,
+		CreateContext: resourceRegionCreate,
+		ReadContext:   resourceRegionRead,
+		UpdateContext: resourceRegionUpdate,
+		DeleteContext: resourceRegionDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -72,85 +72,49 @@ func resourceRegion() *schema.Resource {
 func resourceRegionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	name := strings.ReplaceAll(d.Get(NameField).(string), "'", "")
-	shred := boolToInt(d.Get(SharedField).(bool))
-	enabled := boolToInt(d.Get(EnabledField).(bool))
-
-	server := map[string]interface{}{
-		SharedField:      shred,
-		DescriptionField: description,
-		EnabledField:     enabled,
-		NameField:        name,
-		CountryField:     d.Get(CountryField).(int),
-		GroupIDField:     d.Get(GroupIDField).(int),
-	}
-
-	resp, err := client.doRequest("POST", "/api/v1.0/rmon/region", server)
+	id, err := client.Regions.Create(ctx, expandRegion(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	id, ok := result["id"].(float64)
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
-	}
-
-	d.SetId(fmt.Sprintf("%d", int(id)))
+	d.SetId(strconv.Itoa(id))
 	return resourceRegionRead(ctx, d, m)
 }
 
 func resourceRegionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/rmon/region/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	region, err := client.Regions.Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	description := strings.ReplaceAll(result[DescriptionField].(string), "'", "")
-	name := strings.ReplaceAll(result[NameField].(string), "'", "")
-	d.Set(DescriptionField, description)
-	d.Set(EnabledField, result[EnabledField])
-	d.Set(SharedField, result[SharedField])
-	d.Set(NameField, name)
-	d.Set(CountryField, result[CountryField])
-	d.Set(GroupIDField, result[GroupIDField])
+	d.Set(DescriptionField, strings.ReplaceAll(region.Description, "'", ""))
+	d.Set(EnabledField, bool(region.Enabled))
+	d.Set(SharedField, bool(region.Shared))
+	d.Set(NameField, strings.ReplaceAll(region.Name, "'", ""))
+	d.Set(CountryField, region.CountryID)
+	d.Set(GroupIDField, region.GroupID)
 
 	return nil
 }
 
 func resourceRegionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	name := strings.ReplaceAll(d.Get(NameField).(string), "'", "")
-	shred := boolToInt(d.Get(SharedField).(bool))
-	enabled := boolToInt(d.Get(EnabledField).(bool))
-
-	server := map[string]interface{}{
-		SharedField:      shred,
-		DescriptionField: description,
-		EnabledField:     enabled,
-		NameField:        name,
-		CountryField:     d.Get(CountryField).(int),
-		GroupIDField:     d.Get(GroupIDField).(int),
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/rmon/region/%s", id), server)
-	if err != nil {
+	if err := client.Regions.Update(ctx, id, expandRegion(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -159,13 +123,30 @@ func resourceRegionUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceRegionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/rmon/region/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Regions.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func expandRegion(d *schema.ResourceData) *rmonapi.Region {
+	return &rmonapi.Region{
+		Name:        strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
+		Description: strings.ReplaceAll(d.Get(DescriptionField).(string), "'", ""),
+		Enabled:     rmonapi.Bool(d.Get(EnabledField).(bool)),
+		Shared:      rmonapi.Bool(d.Get(SharedField).(bool)),
+		CountryID:   d.Get(CountryField).(int),
+		GroupID:     d.Get(GroupIDField).(int),
+	}
+}
@@ -0,0 +1,63 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceRegion(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindRegion, "rmon_region"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceRegionConfig("eu-west", true)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindRegion, "rmon_region.test"),
+					testAccStoreID("rmon_region.test", &id),
+					resource.TestCheckResourceAttr("rmon_region.test", "name", "eu-west"),
+					resource.TestCheckResourceAttr("rmon_region.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("rmon_region.test", "country_id", "1"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceRegionConfig("eu-central", false)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_region.test", &id),
+					testAccCheckAttr(srv, rmontest.KindRegion, "rmon_region.test", "enabled", 0),
+					resource.TestCheckResourceAttr("rmon_region.test", "name", "eu-central"),
+					resource.TestCheckResourceAttr("rmon_region.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "rmon_region.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceRegionConfig("eu-central", false)),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindRegion, "rmon_region.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceRegionConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "rmon_region" "test" {
+  name        = %q
+  description = "test region"
+  enabled     = %t
+  shared      = false
+  country_id  = 1
+  group_id    = 1
+}
+`, name, enabled)
+}
@@ -2,14 +2,14 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -23,10 +23,10 @@ const (
 
 func resourceServer() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceServerCreate,
-		ReadWithoutTimeout:   resourceServerRead,
-		UpdateWithoutTimeout: resourceServerUpdate,
-		DeleteWithoutTimeout: resourceServerDelete,
+		CreateContext: resourceServerCreate,
+		ReadContext:   resourceServerRead,
+		UpdateContext: resourceServerUpdate,
+		DeleteContext: resourceServerDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -85,84 +85,50 @@ func resourceServer() *schema.Resource {
 func resourceServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	hostname := strings.ReplaceAll(d.Get(HostnameField).(string), "'", "")
-
-	server := map[string]interface{}{
-		CredIDField:      d.Get(CredIDField).(int),
-		DescriptionField: description,
-		EnabledField:     boolToInt(d.Get(EnabledField).(bool)),
-		GroupIDField:     d.Get(GroupIDField).(int),
-		HostnameField:    hostname,
-		IPField:          d.Get(IPField).(string),
-		PortField:        d.Get(PortField).(int),
-	}
-
-	resp, err := client.doRequest("POST", "/api/v1.0/server", server)
+	id, err := client.Servers.Create(ctx, expandServer(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	id, ok := result["id"].(float64)
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
-	}
-
-	d.SetId(fmt.Sprintf("%d", int(id)))
+	d.SetId(strconv.Itoa(id))
 	return resourceServerRead(ctx, d, m)
 }
 
 func resourceServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/server/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	server, err := client.Servers.Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
-	d.Set(CredIDField, result[CredIDField])
-	description := strings.ReplaceAll(result[DescriptionField].(string), "'", "")
-	hostname := strings.ReplaceAll(result[HostnameField].(string), "'", "")
-	d.Set(DescriptionField, description)
-	d.Set(EnabledField, intToBool(result[EnabledField].(float64)))
-	d.Set(GroupIDField, result[GroupIDField])
-	d.Set(HostnameField, hostname)
-	d.Set(IPField, result[IPField])
-	d.Set(PortField, result[PortField])
+	d.Set(CredIDField, server.CredID)
+	d.Set(DescriptionField, strings.ReplaceAll(server.Description, "'", ""))
+	d.Set(EnabledField, bool(server.Enabled))
+	d.Set(GroupIDField, server.GroupID)
+	d.Set(HostnameField, strings.ReplaceAll(server.Hostname, "'", ""))
+	d.Set(IPField, server.IP)
+	d.Set(PortField, server.Port)
 
 	return nil
 }
 
 func resourceServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	description := strings.ReplaceAll(d.Get(DescriptionField).(string), "'", "")
-	hostname := strings.ReplaceAll(d.Get(HostnameField).(string), "'", "")
-
-	server := map[string]interface{}{
-		CredIDField:      d.Get(CredIDField).(int),
-		DescriptionField: description,
-		EnabledField:     boolToInt(d.Get(EnabledField).(bool)),
-		GroupIDField:     d.Get(GroupIDField).(int),
-		HostnameField:    hostname,
-		IPField:          d.Get(IPField).(string),
-		PortField:        d.Get(PortField).(int),
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/server/%s", id), server)
-	if err != nil {
+	if err := client.Servers.Update(ctx, id, expandServer(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -171,13 +137,31 @@ func resourceServerUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/server/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Servers.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func expandServer(d *schema.ResourceData) *rmonapi.Server {
+	return &rmonapi.Server{
+		CredID:      d.Get(CredIDField).(int),
+		Description: strings.ReplaceAll(d.Get(DescriptionField).(string), "'", ""),
+		Enabled:     rmonapi.Bool(d.Get(EnabledField).(bool)),
+		GroupID:     d.Get(GroupIDField).(int),
+		Hostname:    strings.ReplaceAll(d.Get(HostnameField).(string), "'", ""),
+		IP:          d.Get(IPField).(string),
+		Port:        d.Get(PortField).(int),
+	}
+}
@@ -0,0 +1,73 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceServer(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindServer, "rmon_server"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceServerConfig("redis01", "192.168.1.101", 22)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindServer, "rmon_server.test"),
+					testAccStoreID("rmon_server.test", &id),
+					testAccCheckAttr(srv, rmontest.KindServer, "rmon_server.test", "enabled", 1),
+					resource.TestCheckResourceAttr("rmon_server.test", "hostname", "redis01"),
+					resource.TestCheckResourceAttr("rmon_server.test", "ip", "192.168.1.101"),
+					resource.TestCheckResourceAttr("rmon_server.test", "port", "22"),
+					resource.TestCheckResourceAttr("rmon_server.test", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceServerConfig("redis02", "192.168.1.101", 2222)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_server.test", &id),
+					testAccCheckAttr(srv, rmontest.KindServer, "rmon_server.test", "hostname", "redis02"),
+					resource.TestCheckResourceAttr("rmon_server.test", "hostname", "redis02"),
+					resource.TestCheckResourceAttr("rmon_server.test", "port", "2222"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceServerConfig("redis02", "192.168.1.102", 2222)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckReplaced("rmon_server.test", &id),
+					resource.TestCheckResourceAttr("rmon_server.test", "ip", "192.168.1.102"),
+				),
+			},
+			{
+				ResourceName:      "rmon_server.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceServerConfig("redis02", "192.168.1.102", 2222)),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindServer, "rmon_server.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceServerConfig(hostname, ip string, port int) string {
+	return fmt.Sprintf(`
+resource "rmon_server" "test" {
+  cred_id     = 1
+  description = "test server"
+  enabled     = true
+  group_id    = 1
+  hostname    = %q
+  ip          = %q
+  port        = %d
+}
+`, hostname, ip, port)
+}
@@ -2,11 +2,12 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
 	"strings"
+	"terraform-provider-rmon/rmonapi"
 	"time"
 )
 
@@ -20,10 +21,10 @@ const (
 
 func resourceSSHCredential() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceSSHCredentialCreate,
-		ReadWithoutTimeout:   resourceSSHCredentialRead,
-		UpdateWithoutTimeout: resourceSSHCredentialUpdate,
-		DeleteWithoutTimeout: resourceSSHCredentialDelete,
+		CreateContext: resourceSSHCredentialCreate,
+		ReadContext:   resourceSSHCredentialRead,
+		UpdateContext: resourceSSHCredentialUpdate,
+		DeleteContext: resourceSSHCredentialDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -106,51 +107,24 @@ func resourceSSHCredential() *schema.Resource {
 func resourceSSHCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	sshCred := map[string]interface{}{
-		GroupIDField:    d.Get(GroupIDField).(int),
-		KeyEnabledField: boolToInt(d.Get(KeyEnabledField).(bool)),
-		NameField:       strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
-		PasswordField:   d.Get(PasswordField).(string),
-		UsernameField:   strings.ReplaceAll(d.Get(UsernameField).(string), "'", ""),
-		SharedField:     boolToInt(d.Get(SharedField).(bool)),
+	id, err := client.SSHCredentials.Create(ctx, expandSSHCredential(d))
+	if id != 0 {
+		d.SetId(strconv.Itoa(id))
 	}
-
-	resp, err := client.doRequest("POST", "/api/v1.0/server/cred", sshCred)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.Errorf("unexpected response format, could not unmarshal: %s", string(resp))
-	}
-
-	id, ok := result["id"].(float64)
-	if !ok {
-		return diag.Errorf("unable to find ID in response: %v", result)
+	key := &rmonapi.SSHKey{}
+	if passphrase := d.Get(PassPhraseField).(string); passphrase != "" {
+		key.Passphrase = &passphrase
 	}
-	d.SetId(fmt.Sprintf("%d", int(id)))
-
-	status, ok := result["status"].(string)
-	if !ok || status != "Ok" {
-		return diag.Errorf("unexpected status in response: %v", result)
+	if privateKey := d.Get(PrivateKeyField).(string); privateKey != "" {
+		key.PrivateKey = &privateKey
 	}
 
-	if d.Get(PassPhraseField).(string) != "" || d.Get(PrivateKeyField).(string) != "" {
-		patchData := map[string]interface{}{}
-		if d.Get(PassPhraseField).(string) != "" {
-			patchData[PassPhraseField] = d.Get(PassPhraseField).(string)
-		}
-		if d.Get(PrivateKeyField).(string) != "" {
-			patchData[PrivateKeyField] = d.Get(PrivateKeyField).(string)
-		}
-
-		resp, err := client.doRequest("PATCH", fmt.Sprintf("/api/v1.0/server/cred/%s", d.Id()), patchData)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		if err := json.Unmarshal(resp, &result); err != nil {
+	if key.Passphrase != nil || key.PrivateKey != nil {
+		if err := client.SSHCredentials.UpdateKey(ctx, id, key); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -160,96 +134,66 @@ func resourceSSHCredentialCreate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceSSHCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/server/cred/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var resultArray []map[string]interface{}
-	if err := json.Unmarshal(resp, &resultArray); err != nil {
-		return diag.Errorf("unexpected response format, could not unmarshal: %s", string(resp))
-	}
-
-	if len(resultArray) == 0 {
-		return diag.Errorf("empty array in response")
+	cred, err := client.SSHCredentials.Get(ctx, id)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
-	result := resultArray[0]
 
-	d.Set(GroupIDField, result[GroupIDField])
-	d.Set(KeyEnabledField, intToBool(result[KeyEnabledField].(float64)))
-	name := strings.ReplaceAll(result[NameField].(string), "'", "")
-	d.Set(NameField, name)
-	d.Set(PasswordField, result[PasswordField])
-	username := strings.ReplaceAll(result[UsernameField].(string), "'", "")
-	d.Set(UsernameField, username)
-	d.Set(PassPhraseField, result[PassPhraseField])
-	d.Set(PrivateKeyField, result[PrivateKeyField])
-	d.Set(SharedField, intToBool(result[SharedField].(float64)))
+	d.Set(GroupIDField, cred.GroupID)
+	d.Set(KeyEnabledField, bool(cred.KeyEnabled))
+	d.Set(NameField, strings.ReplaceAll(cred.Name, "'", ""))
+	d.Set(PasswordField, cred.Password)
+	d.Set(UsernameField, strings.ReplaceAll(cred.Username, "'", ""))
+	d.Set(PassPhraseField, cred.Passphrase)
+	d.Set(PrivateKeyField, cred.PrivateKey)
+	d.Set(SharedField, bool(cred.Shared))
 
 	return nil
 }
 
 func resourceSSHCredentialUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	sshCred := map[string]interface{}{
-		GroupIDField:    d.Get(GroupIDField).(int),
-		KeyEnabledField: boolToInt(d.Get(KeyEnabledField).(bool)),
-		NameField:       strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
-		PasswordField:   d.Get(PasswordField).(string),
-		UsernameField:   strings.ReplaceAll(d.Get(UsernameField).(string), "'", ""),
-		SharedField:     boolToInt(d.Get(SharedField).(bool)),
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
+	cred := expandSSHCredential(d)
 	if d.Get(KeyEnabledField).(bool) {
 		privateKey := d.Get(PrivateKeyField).(string)
 		if privateKey == "" {
 			return diag.Errorf("`%s` must be provided when `%s` is true", PrivateKeyField, KeyEnabledField)
 		}
-		sshCred[PrivateKeyField] = privateKey
+		cred.PrivateKey = privateKey
 	}
 
-	resp, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/server/cred/%s", id), sshCred)
-	if err != nil {
+	if err := client.SSHCredentials.Update(ctx, id, cred); err != nil {
 		return diag.FromErr(err)
 	}
 
-	var statusResponse map[string]interface{}
-	if err := json.Unmarshal(resp, &statusResponse); err == nil {
-		if status, ok := statusResponse["status"]; ok && status == "Ok" {
-			patchData := map[string]interface{}{}
-			if d.HasChange(PassPhraseField) {
-				patchData[PassPhraseField] = d.Get(PassPhraseField).(string)
-			}
-			if d.HasChange(PrivateKeyField) {
-				patchData[PrivateKeyField] = d.Get(PrivateKeyField).(string)
-			}
-
-			if len(patchData) > 0 {
-				resp, err := client.doRequest("PATCH", fmt.Sprintf("/api/v1.0/server/cred/%s", d.Id()), patchData)
-				if err != nil {
-					return diag.FromErr(err)
-				}
-
-				if err := json.Unmarshal(resp, &statusResponse); err != nil {
-					return diag.FromErr(err)
-				}
-			}
-
-			return resourceSSHCredentialRead(ctx, d, m)
-		}
+	key := &rmonapi.SSHKey{}
+	if d.HasChange(PassPhraseField) {
+		passphrase := d.Get(PassPhraseField).(string)
+		key.Passphrase = &passphrase
 	}
-
-	var resultArray []map[string]interface{}
-	if err := json.Unmarshal(resp, &resultArray); err != nil {
-		return diag.Errorf("unexpected response format, could not unmarshal: %s", string(resp))
+	if d.HasChange(PrivateKeyField) {
+		privateKey := d.Get(PrivateKeyField).(string)
+		key.PrivateKey = &privateKey
 	}
 
-	if len(resultArray) == 0 {
-		return diag.Errorf("empty array in response")
+	if key.Passphrase != nil || key.PrivateKey != nil {
+		if err := client.SSHCredentials.UpdateKey(ctx, id, key); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceSSHCredentialRead(ctx, d, m)
@@ -257,27 +201,30 @@ func resourceSSHCredentialUpdate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceSSHCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-	id := d.Id()
-
-	resp, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/server/cred/%s", id), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if len(resp) == 0 {
-		d.SetId("")
-		return nil
+	if err := client.SSHCredentials.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
-	var resultArray []map[string]interface{}
-	if err := json.Unmarshal(resp, &resultArray); err != nil {
-		return diag.Errorf("unexpected response format, could not unmarshal: %s", string(resp))
-	}
+	d.SetId("")
+	return nil
+}
 
-	if len(resultArray) > 0 {
-		d.SetId("")
-		return nil
+func expandSSHCredential(d *schema.ResourceData) *rmonapi.SSHCredential {
+	return &rmonapi.SSHCredential{
+		GroupID:    d.Get(GroupIDField).(int),
+		KeyEnabled: rmonapi.Bool(d.Get(KeyEnabledField).(bool)),
+		Name:       strings.ReplaceAll(d.Get(NameField).(string), "'", ""),
+		Password:   d.Get(PasswordField).(string),
+		Username:   strings.ReplaceAll(d.Get(UsernameField).(string), "'", ""),
+		Shared:     rmonapi.Bool(d.Get(SharedField).(bool)),
 	}
-
-	return diag.Errorf("unexpected response format during deletion")
 }
@@ -0,0 +1,78 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceSSHCredential(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindSSHCredential, "rmon_ssh_credential"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceSSHCredentialPasswordConfig("deploy", "secret")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindSSHCredential, "rmon_ssh_credential.test"),
+					testAccStoreID("rmon_ssh_credential.test", &id),
+					resource.TestCheckResourceAttr("rmon_ssh_credential.test", "username", "deploy"),
+					resource.TestCheckResourceAttr("rmon_ssh_credential.test", "key_enabled", "false"),
+					resource.TestCheckResourceAttr("rmon_ssh_credential.test", "shared", "true"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceSSHCredentialKeyConfig("deploy", "a2V5")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_ssh_credential.test", &id),
+					testAccCheckAttr(srv, rmontest.KindSSHCredential, "rmon_ssh_credential.test", "private_key", "a2V5"),
+					testAccCheckAttr(srv, rmontest.KindSSHCredential, "rmon_ssh_credential.test", "key_enabled", 1),
+					resource.TestCheckResourceAttr("rmon_ssh_credential.test", "key_enabled", "true"),
+					resource.TestCheckResourceAttr("rmon_ssh_credential.test", "private_key", "a2V5"),
+				),
+			},
+			{
+				ResourceName:      "rmon_ssh_credential.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceSSHCredentialKeyConfig("deploy", "a2V5")),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindSSHCredential, "rmon_ssh_credential.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSSHCredentialPasswordConfig(username, password string) string {
+	return fmt.Sprintf(`
+resource "rmon_ssh_credential" "test" {
+  group_id    = 1
+  name        = "test_cred"
+  username    = %q
+  password    = %q
+  key_enabled = false
+  shared      = true
+}
+`, username, password)
+}
+
+func testAccResourceSSHCredentialKeyConfig(username, privateKey string) string {
+	return fmt.Sprintf(`
+resource "rmon_ssh_credential" "test" {
+  group_id    = 1
+  name        = "test_cred"
+  username    = %q
+  private_key = %q
+  passphrase  = "phrase"
+  key_enabled = true
+  shared      = true
+}
+`, username, privateKey)
+}
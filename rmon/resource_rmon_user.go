@@ -2,10 +2,10 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strconv"
+	"terraform-provider-rmon/rmonapi"
 	"time"
 )
 
@@ -18,10 +18,10 @@ const (
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceUserCreate,
-		ReadWithoutTimeout:   resourceUserRead,
-		UpdateWithoutTimeout: resourceUserUpdate,
-		DeleteWithoutTimeout: resourceUserDelete,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -65,64 +65,38 @@ func resourceUser() *schema.Resource {
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	user := map[string]interface{}{
-		UserEmailField:    d.Get(UserEmailField).(string),
-		UserEnabledField:  boolToInt(d.Get(UserEnabledField).(bool)),
-		UserPasswordField: d.Get(UserPasswordField).(string),
-		UserUsernameField: d.Get(UserUsernameField).(string),
-	}
-
-	resp, err := client.doRequest("POST", "/api/v1.0/v1.0/user", user)
+	id, err := client.Users.Create(ctx, expandUser(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	id, ok := result["id"]
-	if !ok {
-		return diag.Errorf("unable to extract user ID from response: %v", result)
-	}
-
-	switch v := id.(type) {
-	case string:
-		d.SetId(v)
-	case float64:
-		d.SetId(fmt.Sprintf("%.0f", v))
-	default:
-		return diag.Errorf("unexpected type for user ID: %T", id)
-	}
-
+	d.SetId(strconv.Itoa(id))
 	return resourceUserRead(ctx, d, m)
 }
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/v1.0/user/%s", d.Id()), nil)
+	user, err := client.Users.Get(ctx, id)
 	if err != nil {
-		if isNotFound(err) {
+		if rmonapi.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 		return diag.FromErr(err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := d.Set(UserEmailField, result[UserEmailField]); err != nil {
+	if err := d.Set(UserEmailField, user.Email); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set(UserEnabledField, intToBool(result[UserEnabledField].(float64))); err != nil {
+	if err := d.Set(UserEnabledField, bool(user.Enabled)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set(UserUsernameField, result[UserUsernameField]); err != nil {
+	if err := d.Set(UserUsernameField, user.Username); err != nil {
 		return diag.FromErr(err)
 	}
 	// Note: Password is not set here for security reasons
@@ -132,16 +106,12 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-
-	user := map[string]interface{}{
-		UserEmailField:    d.Get(UserEmailField).(string),
-		UserEnabledField:  boolToInt(d.Get(UserEnabledField).(bool)),
-		UserPasswordField: d.Get(UserPasswordField).(string),
-		UserUsernameField: d.Get(UserUsernameField).(string),
+	id, err := parseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/v1.0/user/%s", d.Id()), user)
-	if err != nil {
+	if err := client.Users.Update(ctx, id, expandUser(d)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -150,12 +120,28 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
-
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/v1.0/user/%s", d.Id()), nil)
+	id, err := parseID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := client.Users.Delete(ctx, id); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
 	d.SetId("")
 	return nil
 }
+
+func expandUser(d *schema.ResourceData) *rmonapi.User {
+	return &rmonapi.User{
+		Email:    d.Get(UserEmailField).(string),
+		Enabled:  rmonapi.Bool(d.Get(UserEnabledField).(bool)),
+		Password: d.Get(UserPasswordField).(string),
+		Username: d.Get(UserUsernameField).(string),
+	}
+}
@@ -2,7 +2,6 @@ package rmon
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
 )
 
 const (
@@ -18,10 +18,10 @@ const (
 
 func resourceUserRoleBinding() *schema.Resource {
 	return &schema.Resource{
-		CreateWithoutTimeout: resourceUserRoleBindingCreate,
-		ReadWithoutTimeout:   resourceUserRoleBindingRead,
-		UpdateWithoutTimeout: resourceUserRoleBindingUpdate,
-		DeleteWithoutTimeout: resourceUserRoleBindingDelete,
+		CreateContext: resourceUserRoleBindingCreate,
+		ReadContext:   resourceUserRoleBindingRead,
+		UpdateContext: resourceUserRoleBindingUpdate,
+		DeleteContext: resourceUserRoleBindingDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -63,17 +63,7 @@ func resourceUserRoleBindingCreate(ctx context.Context, d *schema.ResourceData,
 	userID := d.Get(UserIDField).(int)
 	groupID := d.Get(GroupIDField).(int)
 
-	binding := map[string]interface{}{
-		RoleIDField: d.Get(RoleIDField).(int),
-	}
-
-	resp, err := client.doRequest("POST", fmt.Sprintf("/api/v1.0/user/%d/groups/%d", userID, groupID), binding)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	var result map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := client.RoleBindings.Create(ctx, userID, groupID, d.Get(RoleIDField).(int)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -85,59 +75,38 @@ func resourceUserRoleBindingCreate(ctx context.Context, d *schema.ResourceData,
 func resourceUserRoleBindingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	id := d.Id()
-	ids := strings.Split(id, "-")
-	if len(ids) != 2 {
-		return diag.Errorf("invalid ID format for user role binding: %s", id)
-	}
-	userIDStr := ids[0]
-	groupIDStr := ids[1]
-
-	userID, err := strconv.Atoi(userIDStr)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	groupID, err := strconv.Atoi(groupIDStr)
+	userID, groupID, err := parseUserRoleBindingID(d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	resp, err := client.doRequest("GET", fmt.Sprintf("/api/v1.0/user/%d/groups", userID), nil)
+	bindings, err := client.RoleBindings.List(ctx, userID)
 	if err != nil {
-		if isNotFound(err) {
+		if rmonapi.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 		return diag.FromErr(err)
 	}
 
-	var result []map[string]interface{}
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return diag.FromErr(err)
-	}
-
-	var found bool
-	for _, group := range result {
-		if gid, ok := group["user_group_id"].(float64); ok && int(gid) == groupID {
-			if err := d.Set(UserIDField, userID); err != nil {
-				return diag.FromErr(err)
-			}
-			if err := d.Set(RoleIDField, group["user_role_id"]); err != nil {
-				return diag.FromErr(err)
-			}
-			if err := d.Set(GroupIDField, groupID); err != nil {
-				return diag.FromErr(err)
-			}
-			found = true
-			break
+	for _, binding := range bindings {
+		if binding.GroupID != groupID {
+			continue
 		}
-	}
 
-	if !found {
-		d.SetId("")
+		if err := d.Set(UserIDField, userID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set(RoleIDField, binding.RoleID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set(GroupIDField, groupID); err != nil {
+			return diag.FromErr(err)
+		}
 		return nil
 	}
 
+	d.SetId("")
 	return nil
 }
 
@@ -147,12 +116,7 @@ func resourceUserRoleBindingUpdate(ctx context.Context, d *schema.ResourceData,
 	userID := d.Get(UserIDField).(int)
 	groupID := d.Get(GroupIDField).(int)
 
-	binding := map[string]interface{}{
-		RoleIDField: d.Get(RoleIDField).(int),
-	}
-
-	_, err := client.doRequest("PUT", fmt.Sprintf("/api/v1.0/user/%d/groups/%d", userID, groupID), binding)
-	if err != nil {
+	if err := client.RoleBindings.Update(ctx, userID, groupID, d.Get(RoleIDField).(int)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -162,19 +126,38 @@ func resourceUserRoleBindingUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceUserRoleBindingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*Config).Client
 
-	id := d.Id()
-	ids := strings.Split(id, "-")
-	if len(ids) != 2 {
-		return diag.Errorf("invalid ID format for user role binding: %s", id)
+	userID, groupID, err := parseUserRoleBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	userIDStr := ids[0]
-	groupIDStr := ids[1]
 
-	_, err := client.doRequest("DELETE", fmt.Sprintf("/api/v1.0/user/%s/groups/%s", userIDStr, groupIDStr), nil)
-	if err != nil {
+	if err := client.RoleBindings.Delete(ctx, userID, groupID); err != nil {
+		if rmonapi.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 
 	d.SetId("")
 	return nil
 }
+
+// parseUserRoleBindingID splits an ID of the form <user_id>-<group_id>.
+func parseUserRoleBindingID(id string) (int, int, error) {
+	ids := strings.Split(id, "-")
+	if len(ids) != 2 {
+		return 0, 0, fmt.Errorf("invalid ID format for user role binding: %s", id)
+	}
+
+	userID, err := strconv.Atoi(ids[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	groupID, err := strconv.Atoi(ids[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return userID, groupID, nil
+}
@@ -0,0 +1,144 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceUserRoleBinding(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckUserRoleBindingDestroy(srv),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceUserRoleBindingConfig("rmon_user.first", rmontest.DefaultGroupID, 3)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserRoleBinding(srv, "rmon_user_role_binding.test", 3),
+					testAccStoreID("rmon_user_role_binding.test", &id),
+					resource.TestCheckResourceAttrPair("rmon_user_role_binding.test", "user_id", "rmon_user.first", "id"),
+					resource.TestCheckResourceAttr("rmon_user_role_binding.test", "role_id", "3"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceUserRoleBindingConfig("rmon_user.first", rmontest.DefaultGroupID, 2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_user_role_binding.test", &id),
+					testAccCheckUserRoleBinding(srv, "rmon_user_role_binding.test", 2),
+					resource.TestCheckResourceAttr("rmon_user_role_binding.test", "role_id", "2"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceUserRoleBindingConfig("rmon_user.second", rmontest.DefaultGroupID, 2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckReplaced("rmon_user_role_binding.test", &id),
+					testAccStoreID("rmon_user_role_binding.test", &id),
+					testAccCheckUserRoleBinding(srv, "rmon_user_role_binding.test", 2),
+					resource.TestCheckResourceAttrPair("rmon_user_role_binding.test", "user_id", "rmon_user.second", "id"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceUserRoleBindingConfig("rmon_user.second", "rmon_group.other.id", 2)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckReplaced("rmon_user_role_binding.test", &id),
+					testAccCheckUserRoleBinding(srv, "rmon_user_role_binding.test", 2),
+					resource.TestCheckResourceAttrPair("rmon_user_role_binding.test", "group_id", "rmon_group.other", "id"),
+				),
+			},
+			{
+				ResourceName:      "rmon_user_role_binding.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceUserRoleBindingConfig("rmon_user.second", "rmon_group.other.id", 2)),
+				Check: func(s *terraform.State) error {
+					userID, groupID, err := testAccUserRoleBindingIDs(s, "rmon_user_role_binding.test")
+					if err != nil {
+						return err
+					}
+					srv.DeleteBinding(userID, groupID)
+					return nil
+				},
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccUserRoleBindingIDs(s *terraform.State, name string) (int, int, error) {
+	rs, ok := s.RootModule().Resources[name]
+	if !ok {
+		return 0, 0, fmt.Errorf("resource %s not found in state", name)
+	}
+	return parseUserRoleBindingID(rs.Primary.ID)
+}
+
+func testAccCheckUserRoleBinding(srv *rmontest.Server, name string, wantRoleID int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		userID, groupID, err := testAccUserRoleBindingIDs(s, name)
+		if err != nil {
+			return err
+		}
+		roleID, ok := srv.Binding(userID, groupID)
+		if !ok {
+			return fmt.Errorf("user %d is not bound to group %d in RMON", userID, groupID)
+		}
+		if roleID != wantRoleID {
+			return fmt.Errorf("user %d has role %d in group %d, want %d", userID, roleID, groupID, wantRoleID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckUserRoleBindingDestroy(srv *rmontest.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "rmon_user_role_binding" {
+				continue
+			}
+			userID, groupID, err := parseUserRoleBindingID(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if _, ok := srv.Binding(userID, groupID); ok {
+				return fmt.Errorf("user %d is still bound to group %d in RMON", userID, groupID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccResourceUserRoleBindingConfig(user string, groupID interface{}, roleID int) string {
+	return fmt.Sprintf(`
+resource "rmon_user" "first" {
+  username = "first"
+  email    = "first@example.com"
+  password = "s3cret"
+  enabled  = true
+}
+
+resource "rmon_user" "second" {
+  username = "second"
+  email    = "second@example.com"
+  password = "s3cret"
+  enabled  = true
+}
+
+resource "rmon_group" "other" {
+  name = "other"
+}
+
+resource "rmon_user_role_binding" "test" {
+  user_id  = %s.id
+  group_id = %v
+  role_id  = %d
+}
+`, user, groupID, roleID)
+}
@@ -0,0 +1,63 @@
+package rmon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestAccResourceUser(t *testing.T) {
+	srv := testAccServer(t)
+	var id string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(srv, rmontest.KindUser, "rmon_user"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccConfig(srv, testAccResourceUserConfig("jdoe", "jdoe@example.com", true)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(srv, rmontest.KindUser, "rmon_user.test"),
+					testAccStoreID("rmon_user.test", &id),
+					resource.TestCheckResourceAttr("rmon_user.test", "username", "jdoe"),
+					resource.TestCheckResourceAttr("rmon_user.test", "email", "jdoe@example.com"),
+					resource.TestCheckResourceAttr("rmon_user.test", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccConfig(srv, testAccResourceUserConfig("jdoe", "john.doe@example.com", false)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotReplaced("rmon_user.test", &id),
+					testAccCheckAttr(srv, rmontest.KindUser, "rmon_user.test", "enabled", 0),
+					resource.TestCheckResourceAttr("rmon_user.test", "email", "john.doe@example.com"),
+					resource.TestCheckResourceAttr("rmon_user.test", "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "rmon_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// RMON never returns the password.
+				ImportStateVerifyIgnore: []string{UserPasswordField},
+			},
+			{
+				Config:             testAccConfig(srv, testAccResourceUserConfig("jdoe", "john.doe@example.com", false)),
+				Check:              testAccDeleteOutOfBand(srv, rmontest.KindUser, "rmon_user.test"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccResourceUserConfig(username, email string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "rmon_user" "test" {
+  username = %q
+  email    = %q
+  password = "s3cret"
+  enabled  = %t
+}
+`, username, email, enabled)
+}
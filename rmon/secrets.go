@@ -0,0 +1,112 @@
+package rmon
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// secretSchema returns the schema of a sensitive attribute together with
+// <field>_file and <field>_env, which read the value from a file or an
+// environment variable at apply time so that it never ends up in the
+// configuration. With required set, exactly one of the three must be given.
+func secretSchema(field, description string, required bool) map[string]*schema.Schema {
+	fileField, envField := field+"_file", field+"_env"
+	alternatives := []string{field, fileField, envField}
+
+	schemas := map[string]*schema.Schema{
+		field: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: description,
+		},
+		fileField: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Path to a file holding `%s`. Surrounding whitespace is trimmed. Changing the file content alone is not detected.", field),
+		},
+		envField: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Name of an environment variable holding `%s`. Changing the variable alone is not detected.", field),
+		},
+	}
+
+	for name, s := range schemas {
+		if required {
+			s.ExactlyOneOf = alternatives
+		} else {
+			s.ConflictsWith = otherStrings(alternatives, name)
+		}
+	}
+	return schemas
+}
+
+// secretIsSet reports whether the secret is configured in any of its forms.
+func secretIsSet(d interface {
+	GetOk(string) (interface{}, bool)
+}, field string) bool {
+	for _, name := range []string{field, field + "_file", field + "_env"} {
+		if _, ok := d.GetOk(name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expandSecret returns the secret from the attribute itself, its file or its
+// environment variable.
+func expandSecret(d *schema.ResourceData, field string) (string, error) {
+	if path, ok := d.GetOk(field + "_file"); ok {
+		data, err := os.ReadFile(path.(string))
+		if err != nil {
+			return "", fmt.Errorf("reading `%s_file`: %w", field, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	if name, ok := d.GetOk(field + "_env"); ok {
+		value, found := os.LookupEnv(name.(string))
+		if !found || value == "" {
+			return "", fmt.Errorf("environment variable %s set in `%s_env` is empty", name, field)
+		}
+		return value, nil
+	}
+
+	return d.Get(field).(string), nil
+}
+
+// flattenSecret stores a secret returned by RMON, unless RMON only returned a
+// redacted form of it or the configuration reads it from a file or the
+// environment. Either way, the configured value is kept.
+func flattenSecret(d *schema.ResourceData, field, value string) {
+	if isRedacted(value) {
+		return
+	}
+	if _, ok := d.GetOk(field + "_file"); ok {
+		return
+	}
+	if _, ok := d.GetOk(field + "_env"); ok {
+		return
+	}
+	d.Set(field, value)
+}
+
+// isRedacted reports whether RMON masked a secret, e.g. "******" or
+// "abcd****", instead of returning it.
+func isRedacted(value string) bool {
+	return strings.Trim(value, "*") == "" || strings.Contains(value, "***")
+}
+
+func otherStrings(values []string, exclude string) []string {
+	var others []string
+	for _, value := range values {
+		if value != exclude {
+			others = append(others, value)
+		}
+	}
+	return others
+}
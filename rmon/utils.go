@@ -1,12 +1,71 @@
 package rmon
 
-func boolToInt(b bool) int {
-	if b {
-		return 1
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Utility function to validate a Go duration string such as "500ms" or "2m"
+func validateDuration(val interface{}, key string) (warns []string, errs []error) {
+	d, err := time.ParseDuration(val.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a valid duration (e.g. \"30s\"): %v", key, err))
+		return
+	}
+	if d < 0 {
+		errs = append(errs, fmt.Errorf("%q must not be negative", key))
+	}
+	return
+}
+
+// Utility function to convert a Terraform resource ID into a numeric RMON ID
+func parseID(id string) (int, error) {
+	value, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q: must be a number", id)
+	}
+	return value, nil
+}
+
+// Utility function to list allowed values in a description, e.g. "`a`, `b`"
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "`"+value+"`")
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Utility function to check whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
-	return 0
+	return false
 }
 
-func intToBool(i float64) bool {
-	return i == 1
+// Utility function to combine several schema maps into one
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := make(map[string]*schema.Schema)
+	for _, s := range schemas {
+		for field, fieldSchema := range s {
+			merged[field] = fieldSchema
+		}
+	}
+	return merged
+}
+
+// Utility function to read an optional boolean filter of a data source.
+// ok is false when the attribute is not set in the configuration.
+func getOptionalBool(d *schema.ResourceData, field string) (value bool, ok bool) {
+	if d.GetRawConfig().GetAttr(field).IsNull() {
+		return false, false
+	}
+	return d.Get(field).(bool), true
 }
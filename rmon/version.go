@@ -0,0 +1,125 @@
+package rmon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"terraform-provider-rmon/rmonapi"
+)
+
+// checkFieldMinVersions lists check attributes that older RMON releases reject
+// with a 400, keyed by attribute name. Notification receivers are keyed by
+// the RMON field holding their channel ID, and the filters of a notification
+// block by their name.
+var checkFieldMinVersions = map[string]string{
+	RetriesField:           "1.1.0",
+	RunbookField:           "1.1.0",
+	RedirectsField:         "1.2.0",
+	NotificationListField:  "1.2.0",
+	SeverityField:          "1.2.0",
+	TriggerField:           "1.2.0",
+	EmailChannelIDField:    "1.2.0",
+	WebhookChannelIDField:  "1.2.0",
+	TeamsChannelIDField:    "1.2.0",
+	DiscordChannelIDField:  "1.2.0",
+	OpsgenieChannelIDField: "1.2.0",
+}
+
+// preVersionEndpoint stands for the RMON releases that answer /version with a
+// 404. The endpoint is older than every attribute in checkFieldMinVersions.
+var preVersionEndpoint = version.Must(version.NewVersion("0.0.0"))
+
+func detectServerVersion(ctx context.Context, client *rmonapi.Client) (*version.Version, error) {
+	raw, err := client.ServerVersion(ctx)
+	if err != nil {
+		if rmonapi.IsNotFound(err) {
+			return preVersionEndpoint, nil
+		}
+		return nil, err
+	}
+	return version.NewVersion(raw)
+}
+
+// supportsField reports whether the connected RMON accepts the given check
+// attribute. A server whose version could not be detected, e.g. because of a
+// transient error, is assumed to support everything.
+func (c *Config) supportsField(field string) bool {
+	return c.checkMinVersion(field) == nil
+}
+
+func (c *Config) checkMinVersion(field string) error {
+	minRaw, ok := checkFieldMinVersions[field]
+	if !ok || c == nil || c.ServerVersion == nil {
+		return nil
+	}
+
+	minVersion := version.Must(version.NewVersion(minRaw))
+	if c.ServerVersion.LessThan(minVersion) {
+		if c.ServerVersion.Equal(preVersionEndpoint) {
+			return fmt.Errorf("`%s` requires RMON >= %s, but the server predates the version endpoint", field, minVersion)
+		}
+		return fmt.Errorf("`%s` requires RMON >= %s, but the server reports version %s", field, minVersion, c.ServerVersion)
+	}
+	return nil
+}
+
+// gatedInt returns the attribute value for a request body, or nil if the
+// connected RMON does not know the attribute, so that defaults such as
+// `retries` do not break older servers.
+func (c *Config) gatedInt(d *schema.ResourceData, field string) *int {
+	if !c.supportsField(field) {
+		return nil
+	}
+	value := d.Get(field).(int)
+	return &value
+}
+
+func (c *Config) gatedString(d *schema.ResourceData, field string) *string {
+	if !c.supportsField(field) {
+		return nil
+	}
+	value := d.Get(field).(string)
+	return &value
+}
+
+// gatedChannelID returns an empty channel ID for a request body, which
+// expandNotifications fills in, or nil if the connected RMON does not know the
+// field. Sending 0 rather than leaving it out clears a removed receiver.
+func (c *Config) gatedChannelID(field string) *int {
+	if !c.supportsField(field) {
+		return nil
+	}
+	return new(int)
+}
+
+// checkFieldVersionsDiff rejects plans that explicitly set attributes the
+// connected RMON is too old for.
+func checkFieldVersionsDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config, ok := m.(*Config)
+	if !ok {
+		return nil
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	for field := range checkFieldMinVersions {
+		if !rawConfig.Type().HasAttribute(field) {
+			continue
+		}
+		// Absent blocks show up as an empty list rather than null.
+		value := rawConfig.GetAttr(field)
+		if value.IsNull() || (value.IsKnown() && value.CanIterateElements() && value.LengthInt() == 0) {
+			continue
+		}
+		if err := config.checkMinVersion(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Agent statuses reported by AgentService.Status.
+const (
+	AgentOnline  = "online"
+	AgentOffline = "offline"
+)
+
+type Agent struct {
+	// ID is only set on agents returned by List.
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     Bool   `json:"enabled"`
+	Shared      Bool   `json:"shared"`
+	ServerID    int    `json:"server_id"`
+	Port        int    `json:"port"`
+	RegionID    int    `json:"region_id"`
+	// Reconfigure asks RMON to redeploy the agent on its server. It is
+	// write-only and required whenever the port changes.
+	Reconfigure bool `json:"reconfigure"`
+}
+
+// AgentStatus is the live state of an agent, as last seen by RMON.
+type AgentStatus struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+type AgentService struct {
+	client *Client
+}
+
+func (s *AgentService) Create(ctx context.Context, agent *Agent) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/rmon/agent", agent)
+}
+
+func (s *AgentService) Get(ctx context.Context, id int) (*Agent, error) {
+	var agent Agent
+	if err := s.client.Do(ctx, "GET", agentPath(id), nil, &agent); err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// List returns every agent registered in RMON.
+func (s *AgentService) List(ctx context.Context) ([]Agent, error) {
+	var agents []Agent
+	if err := s.client.Do(ctx, "GET", apiPrefix+"/rmon/agent", nil, &agents); err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
+
+// Status returns whether the agent is online and which version it runs.
+func (s *AgentService) Status(ctx context.Context, id int) (*AgentStatus, error) {
+	var status AgentStatus
+	if err := s.client.Do(ctx, "GET", agentPath(id)+"/status", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (s *AgentService) Update(ctx context.Context, id int, agent *Agent) error {
+	return s.client.Do(ctx, "PUT", agentPath(id), agent, nil)
+}
+
+func (s *AgentService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", agentPath(id), nil, nil)
+}
+
+func agentPath(id int) string {
+	return fmt.Sprintf("%s/rmon/agent/%d", apiPrefix, id)
+}
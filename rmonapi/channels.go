@@ -0,0 +1,80 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Channel is an alert destination. Receiver selects the integration, such as
+// telegram or slack, and is part of every channel URL.
+type Channel struct {
+	// ID is only set on channels returned by List.
+	ID       int    `json:"id,omitempty"`
+	Receiver string `json:"receiver"`
+	Channel  string `json:"channel"`
+	GroupID  int    `json:"group_id"`
+	Token    string `json:"token"`
+	// WebhookURL is used by the webhook, teams and discord receivers.
+	WebhookURL string `json:"webhook_url,omitempty"`
+	// HMACSecret signs webhook requests. RMON does not return it.
+	HMACSecret      string            `json:"hmac_secret,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	PayloadTemplate string            `json:"payload_template,omitempty"`
+}
+
+type ChannelService struct {
+	client *Client
+}
+
+func (s *ChannelService) Create(ctx context.Context, channel *Channel) (int, error) {
+	return s.client.create(ctx, fmt.Sprintf("%s/channel/%s", apiPrefix, channel.Receiver), channel)
+}
+
+func (s *ChannelService) Get(ctx context.Context, receiver string, id int) (*Channel, error) {
+	var channel Channel
+	if err := s.client.Do(ctx, "GET", channelPath(receiver, id), nil, &channel); err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// List returns every channel of the given receiver type.
+func (s *ChannelService) List(ctx context.Context, receiver string) ([]Channel, error) {
+	var channels []Channel
+	if err := s.client.Do(ctx, "GET", fmt.Sprintf("%s/channel/%s", apiPrefix, receiver), nil, &channels); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+func (s *ChannelService) Update(ctx context.Context, id int, channel *Channel) error {
+	return s.client.Do(ctx, "PUT", channelPath(channel.Receiver, id), channel, nil)
+}
+
+func (s *ChannelService) Delete(ctx context.Context, receiver string, id int) error {
+	return s.client.Do(ctx, "DELETE", channelPath(receiver, id), nil, nil)
+}
+
+// Test asks RMON to send a test message through the channel. Delivery
+// failures are reported either with an error status code or with a "failed"
+// status, depending on the receiver.
+func (s *ChannelService) Test(ctx context.Context, receiver string, id int) error {
+	var result struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}
+
+	path := channelPath(receiver, id) + "/test"
+	if err := s.client.Do(ctx, "POST", path, nil, &result); err != nil {
+		return err
+	}
+	if strings.EqualFold(result.Status, "failed") {
+		return fmt.Errorf("POST %s: test message failed: %s", path, result.Error)
+	}
+	return nil
+}
+
+func channelPath(receiver string, id int) string {
+	return fmt.Sprintf("%s/channel/%s/%d", apiPrefix, receiver, id)
+}
@@ -0,0 +1,41 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckGroup groups checks for display and alerting. Checks refer to it by
+// name.
+type CheckGroup struct {
+	Name    string `json:"name"`
+	GroupID int    `json:"group_id"`
+}
+
+type CheckGroupService struct {
+	client *Client
+}
+
+func (s *CheckGroupService) Create(ctx context.Context, group *CheckGroup) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/rmon/check-group", group)
+}
+
+func (s *CheckGroupService) Get(ctx context.Context, id int) (*CheckGroup, error) {
+	var group CheckGroup
+	if err := s.client.Do(ctx, "GET", checkGroupPath(id), nil, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (s *CheckGroupService) Update(ctx context.Context, id int, group *CheckGroup) error {
+	return s.client.Do(ctx, "PUT", checkGroupPath(id), group, nil)
+}
+
+func (s *CheckGroupService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", checkGroupPath(id), nil, nil)
+}
+
+func checkGroupPath(id int) string {
+	return fmt.Sprintf("%s/rmon/check-group/%d", apiPrefix, id)
+}
@@ -0,0 +1,227 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// CheckBase holds the attributes shared by every check type.
+type CheckBase struct {
+	// ID is only set on checks returned by List.
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     Bool   `json:"enabled"`
+	// CheckGroup is the name of the check group, not its ID.
+	CheckGroup string `json:"check_group"`
+	// Place is one of all, country, region or agent; Entities holds the IDs
+	// of the selected countries, regions or agents.
+	Place             string `json:"place"`
+	Entities          []int  `json:"entities"`
+	Interval          int    `json:"interval"`
+	Timeout           int    `json:"check_timeout"`
+	TelegramChannelID int    `json:"telegram_channel_id"`
+	SlackChannelID    int    `json:"slack_channel_id"`
+	MMChannelID       int    `json:"mm_channel_id"`
+	PDChannelID       int    `json:"pd_channel_id"`
+	// The channel IDs of the email, webhook, Teams, Discord and Opsgenie
+	// receivers are only known to RMON 1.2.0 and later; leave them nil for
+	// older servers.
+	EmailChannelID    *int `json:"email_channel_id,omitempty"`
+	WebhookChannelID  *int `json:"webhook_channel_id,omitempty"`
+	TeamsChannelID    *int `json:"teams_channel_id,omitempty"`
+	DiscordChannelID  *int `json:"discord_channel_id,omitempty"`
+	OpsgenieChannelID *int `json:"opsgenie_channel_id,omitempty"`
+	// Notifications carries the per-channel alert filters. RMON still routes
+	// by the per-receiver channel IDs above, so those must be filled in too.
+	// It is only known to RMON 1.2.0 and later: leave it nil for older
+	// servers, and point it at an empty list to clear it.
+	Notifications *[]Notification `json:"notifications,omitempty"`
+	// Retries and Runbook are only known to RMON 1.1.0 and later; leave them
+	// nil for older servers.
+	Retries     *int    `json:"retries,omitempty"`
+	Runbook     *string `json:"runbook,omitempty"`
+	Reconfigure bool    `json:"reconfigure,omitempty"`
+}
+
+// Notification sends the alerts of a check to a channel. Severity and Trigger
+// are optional filters; empty means every alert.
+type Notification struct {
+	ChannelID int    `json:"channel_id"`
+	Receiver  string `json:"receiver"`
+	Severity  string `json:"severity,omitempty"`
+	Trigger   string `json:"trigger,omitempty"`
+}
+
+// NotificationList returns the notifications of the check, or nil if the
+// server did not send the list.
+func (c *CheckBase) NotificationList() []Notification {
+	if c.Notifications == nil {
+		return nil
+	}
+	return *c.Notifications
+}
+
+// Base gives access to the common attributes of any check type that embeds
+// CheckBase.
+func (c *CheckBase) Base() *CheckBase {
+	return c
+}
+
+// Check is implemented by pointers to every check type.
+type Check interface {
+	Base() *CheckBase
+}
+
+type HTTPCheck struct {
+	CheckBase
+	URL                 string `json:"url"`
+	Method              string `json:"method"`
+	IgnoreSSLError      Bool   `json:"ignore_ssl_error"`
+	AcceptedStatusCodes int    `json:"accepted_status_codes"`
+	Body                string `json:"body"`
+	BodyRequest         string `json:"body_req"`
+	HeaderRequest       string `json:"header_req"`
+	// Redirects requires RMON 1.2.0.
+	Redirects *int `json:"redirects,omitempty"`
+}
+
+type TCPCheck struct {
+	CheckBase
+	IP   string `json:"ip"`
+	Port int    `json:"port"`
+}
+
+type PingCheck struct {
+	CheckBase
+	IP         string `json:"ip"`
+	PacketSize int    `json:"packet_size"`
+}
+
+type DNSCheck struct {
+	CheckBase
+	IP         string `json:"ip"`
+	Port       int    `json:"port"`
+	Resolver   string `json:"resolver"`
+	RecordType string `json:"record_type"`
+}
+
+type SMTPCheck struct {
+	CheckBase
+	IP             string `json:"ip"`
+	Port           int    `json:"port"`
+	IgnoreSSLError Bool   `json:"ignore_ssl_error"`
+	Username       string `json:"username"`
+	Password       string `json:"password"`
+}
+
+type RabbitMQCheck struct {
+	CheckBase
+	IP       string `json:"ip"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Vhost    string `json:"vhost"`
+}
+
+// Check statuses reported by CheckServices.Status. A check is unknown until
+// an agent has run it.
+const (
+	CheckUp      = "up"
+	CheckDown    = "down"
+	CheckUnknown = "unknown"
+)
+
+// CheckStatus is the current state of a check. The top-level fields
+// summarize the results of all agents running it.
+type CheckStatus struct {
+	Status string `json:"status"`
+	// LastCheck is the RFC 3339 time of the last run, empty before the first.
+	LastCheck string `json:"last_check"`
+	// ResponseTime is in milliseconds.
+	ResponseTime float64            `json:"response_time"`
+	Error        string             `json:"error"`
+	Agents       []AgentCheckResult `json:"agents"`
+}
+
+// AgentCheckResult is the last result of a check on one agent.
+type AgentCheckResult struct {
+	AgentID      int     `json:"agent_id"`
+	Status       string  `json:"status"`
+	LastCheck    string  `json:"last_check"`
+	ResponseTime float64 `json:"response_time"`
+	Error        string  `json:"error"`
+}
+
+// CheckService manages one check type. All check types share the same
+// endpoints under /rmon/check/{type}.
+type CheckService[T any] struct {
+	client    *Client
+	checkType string
+}
+
+func (s *CheckService[T]) Create(ctx context.Context, check *T) (int, error) {
+	return s.client.create(ctx, fmt.Sprintf("%s/rmon/check/%s", apiPrefix, s.checkType), check)
+}
+
+func (s *CheckService[T]) Get(ctx context.Context, id int) (*T, error) {
+	check := new(T)
+	if err := s.client.Do(ctx, "GET", s.path(id), nil, check); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// List returns every check of the service's type.
+func (s *CheckService[T]) List(ctx context.Context) ([]T, error) {
+	var checks []T
+	if err := s.client.Do(ctx, "GET", fmt.Sprintf("%s/rmon/check/%s", apiPrefix, s.checkType), nil, &checks); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+func (s *CheckService[T]) Update(ctx context.Context, id int, check *T) error {
+	return s.client.Do(ctx, "PUT", s.path(id), check, nil)
+}
+
+func (s *CheckService[T]) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", s.path(id), nil, nil)
+}
+
+func (s *CheckService[T]) path(id int) string {
+	return fmt.Sprintf("%s/rmon/check/%s/%d", apiPrefix, s.checkType, id)
+}
+
+type CheckServices struct {
+	client *Client
+
+	HTTP     *CheckService[HTTPCheck]
+	TCP      *CheckService[TCPCheck]
+	Ping     *CheckService[PingCheck]
+	DNS      *CheckService[DNSCheck]
+	SMTP     *CheckService[SMTPCheck]
+	RabbitMQ *CheckService[RabbitMQCheck]
+}
+
+func newCheckServices(c *Client) *CheckServices {
+	return &CheckServices{
+		client:   c,
+		HTTP:     &CheckService[HTTPCheck]{client: c, checkType: "http"},
+		TCP:      &CheckService[TCPCheck]{client: c, checkType: "tcp"},
+		Ping:     &CheckService[PingCheck]{client: c, checkType: "ping"},
+		DNS:      &CheckService[DNSCheck]{client: c, checkType: "dns"},
+		SMTP:     &CheckService[SMTPCheck]{client: c, checkType: "smtp"},
+		RabbitMQ: &CheckService[RabbitMQCheck]{client: c, checkType: "rabbitmq"},
+	}
+}
+
+// Status returns the current state of a check. It works for every check type,
+// so it is not tied to a single CheckService.
+func (s *CheckServices) Status(ctx context.Context, checkType string, id int) (*CheckStatus, error) {
+	var status CheckStatus
+	if err := s.client.Do(ctx, "GET", fmt.Sprintf("%s/rmon/check/%s/%d/status", apiPrefix, checkType, id), nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,445 @@
+// Package rmonapi is a typed client for the RMON REST API. It is used by the
+// Terraform provider and can be imported by other Go tools.
+package rmonapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenExpiredMarkers are body fragments RMON returns with a 403 when the JWT
+// is no longer valid, as opposed to a plain permission error.
+var tokenExpiredMarkers = []string{
+	"expired",
+	"invalid token",
+	"token is invalid",
+}
+
+// Config holds the settings used to build a Client.
+type Config struct {
+	BaseURL  string
+	Login    string
+	Password string
+	// APIToken is used as the bearer token as-is; when set, the client never
+	// calls the login endpoint.
+	APIToken  string
+	UserAgent string
+	TLS       TLSConfig
+	// ProxyURL overrides the HTTPS_PROXY/NO_PROXY environment variables.
+	ProxyURL *url.URL
+	// Headers are added to every request, including the login call.
+	Headers map[string]string
+	Retry   RetryPolicy
+	// MaxRequestsPerSecond and MaxConcurrentRequests throttle the client
+	// across all resources. Zero disables the respective limit.
+	MaxRequestsPerSecond  float64
+	MaxConcurrentRequests int
+	// RequestTimeout bounds every individual HTTP call, including each retry
+	// attempt. Zero means no per-request limit beyond the caller's context.
+	RequestTimeout time.Duration
+}
+
+// Client talks to the RMON REST API. The typed services cover the objects the
+// Terraform provider manages; Do is available for anything else.
+type Client struct {
+	Groups         *GroupService
+	Servers        *ServerService
+	SSHCredentials *SSHCredentialService
+	Agents         *AgentService
+	Regions        *RegionService
+	Countries      *CountryService
+	Channels       *ChannelService
+	Users          *UserService
+	Roles          *RoleService
+	RoleBindings   *RoleBindingService
+	CheckGroups    *CheckGroupService
+	Checks         *CheckServices
+
+	baseURL    string
+	httpClient *http.Client
+	login      string
+	password   string
+	userAgent  string
+	headers    map[string]string
+	retry      RetryPolicy
+	timeout    time.Duration
+	limiter    *rateLimiter
+	inFlight   semaphore
+
+	// authMu serializes re-authentication so that parallel requests hitting
+	// an expired token trigger a single login.
+	authMu  sync.Mutex
+	tokenMu sync.RWMutex
+	token   string
+}
+
+// apiResponse is a fully read HTTP response.
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// NewClient builds a Client and, unless an API token is configured, logs in
+// to RMON to obtain a bearer token.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	tlsConfig, err := cfg.TLS.build()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if cfg.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(cfg.ProxyURL)
+	}
+
+	client := &Client{
+		baseURL:    cfg.BaseURL,
+		httpClient: &http.Client{Transport: transport},
+		login:      cfg.Login,
+		password:   cfg.Password,
+		userAgent:  cfg.UserAgent,
+		headers:    cfg.Headers,
+		retry:      cfg.Retry,
+		timeout:    cfg.RequestTimeout,
+		limiter:    newRateLimiter(cfg.MaxRequestsPerSecond),
+		inFlight:   newSemaphore(cfg.MaxConcurrentRequests),
+	}
+	client.initServices()
+
+	if cfg.APIToken != "" {
+		client.token = cfg.APIToken
+		return client, nil
+	}
+
+	if err := client.authenticate(withAPILogging(ctx)); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (c *Client) authenticate(ctx context.Context) error {
+	authData := map[string]string{
+		"login":    c.login,
+		"password": c.password,
+	}
+
+	reqBody, err := json.Marshal(authData)
+	if err != nil {
+		return err
+	}
+
+	// Login is a POST, so only failures to reach RMON at all are retried.
+	resp, err := c.sendWithRetry(ctx, "POST", apiPrefix+"/login", func(ctx context.Context) (*apiResponse, error) {
+		return c.send(ctx, "POST", apiPrefix+"/login", reqBody, "")
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return newError("POST", apiPrefix+"/login", resp)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return err
+	}
+
+	token, ok := result["access_token"].(string)
+	if !ok {
+		return fmt.Errorf("unable to find access_token in login response")
+	}
+
+	c.tokenMu.Lock()
+	c.token = token
+	c.tokenMu.Unlock()
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Authenticated against RMON", map[string]interface{}{
+		"login": c.login,
+	})
+	return nil
+}
+
+func (c *Client) currentToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
+
+// reauthenticate logs in again unless another goroutine has already replaced
+// staleToken while we were waiting for the lock.
+func (c *Client) reauthenticate(ctx context.Context, staleToken string) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.currentToken() != staleToken {
+		return nil
+	}
+
+	return c.authenticate(ctx)
+}
+
+// Do sends a request to path, relative to the RMON base URL, and decodes the
+// JSON response into out. out may be nil when the response is not needed.
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	resp, err := c.doRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(bytes.TrimSpace(resp)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp, out); err != nil {
+		return fmt.Errorf("%s %s: unexpected response format: %w", method, path, err)
+	}
+	return nil
+}
+
+// create POSTs body to path and returns the ID of the new object.
+func (c *Client) create(ctx context.Context, path string, body interface{}) (int, error) {
+	var result createResponse
+	if err := c.Do(ctx, "POST", path, body, &result); err != nil {
+		return 0, err
+	}
+
+	if result.ID == 0 {
+		return 0, fmt.Errorf("POST %s: unable to find ID in response", path)
+	}
+	return int(result.ID), nil
+}
+
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}) ([]byte, error) {
+	var reqBody []byte
+	var err error
+	if body != nil {
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx = withAPILogging(ctx)
+
+	resp, err := c.sendWithRetry(ctx, method, endpoint, func(ctx context.Context) (*apiResponse, error) {
+		return c.sendAuthenticated(ctx, method, endpoint, reqBody)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Body, nil
+	}
+	return nil, newError(method, endpoint, resp)
+}
+
+// sendWithRetry calls send until it succeeds, fails permanently or the retry
+// policy is exhausted. The last response is returned as is, even if its
+// status is an error.
+func (c *Client) sendWithRetry(ctx context.Context, method, endpoint string, send func(ctx context.Context) (*apiResponse, error)) (*apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		ctx := tflog.SubsystemSetField(ctx, apiLogSubsystem, "attempt", attempt+1)
+
+		resp, err := send(ctx)
+		if err != nil {
+			// Cancellation and the resource timeout are final; only the
+			// per-request timeout is treated as a transient failure.
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt < c.retry.MaxRetries && c.retry.shouldRetryError(method, err) {
+				wait := c.retry.backoff(attempt, nil)
+				tflog.SubsystemWarn(ctx, apiLogSubsystem, "RMON API request failed, retrying", map[string]interface{}{
+					"method":       method,
+					"path":         endpoint,
+					"max_attempts": c.retry.MaxRetries + 1,
+					"retry_in":     wait.String(),
+					"error":        err.Error(),
+				})
+				if err := sleepContext(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		if attempt < c.retry.MaxRetries && c.retry.shouldRetryStatus(method, resp.StatusCode) {
+			wait := c.retry.backoff(attempt, resp.Header)
+			tflog.SubsystemWarn(ctx, apiLogSubsystem, "RMON API returned a retryable status, retrying", map[string]interface{}{
+				"method":       method,
+				"path":         endpoint,
+				"status_code":  resp.StatusCode,
+				"max_attempts": c.retry.MaxRetries + 1,
+				"retry_in":     wait.String(),
+			})
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return resp, nil
+	}
+}
+
+// sendAuthenticated sends the request with the current token and, if RMON
+// rejects the token, logs in again and replays the request once.
+func (c *Client) sendAuthenticated(ctx context.Context, method, endpoint string, reqBody []byte) (*apiResponse, error) {
+	token := c.currentToken()
+	resp, err := c.send(ctx, method, endpoint, reqBody, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// A static API token cannot be refreshed, so its rejection is final.
+	if c.login == "" || !isTokenExpired(resp.StatusCode, resp.Body) {
+		return resp, nil
+	}
+
+	if err := c.reauthenticate(ctx, token); err != nil {
+		return nil, fmt.Errorf("re-authentication failed: %w", err)
+	}
+
+	return c.send(ctx, method, endpoint, reqBody, c.currentToken())
+}
+
+func (c *Client) send(ctx context.Context, method, endpoint string, reqBody []byte, token string) (*apiResponse, error) {
+	reqURL := fmt.Sprintf("%s%s", c.baseURL, endpoint)
+
+	// Throttling happens before the per-request timeout starts so that time
+	// spent queued does not count against it.
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.inFlight.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.inFlight.Release()
+
+	ctx, cancel := c.withRequestTimeout(ctx)
+	defer cancel()
+
+	req, err := c.newRequest(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	if id := correlationID(ctx); id != "" {
+		req.Header.Set("X-Request-Id", id)
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Sending RMON API request", map[string]interface{}{
+		"method": method,
+		"path":   endpoint,
+	})
+	tflog.SubsystemTrace(ctx, apiLogSubsystem, "RMON API request body", map[string]interface{}{
+		"body": redactBody(reqBody),
+	})
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, apiLogSubsystem, "RMON API request failed", map[string]interface{}{
+			"method":     method,
+			"path":       endpoint,
+			"latency_ms": time.Since(start).Milliseconds(),
+			"error":      err.Error(),
+		})
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.SubsystemDebug(ctx, apiLogSubsystem, "Received RMON API response", map[string]interface{}{
+		"method":      method,
+		"path":        endpoint,
+		"status_code": resp.StatusCode,
+		"latency_ms":  time.Since(start).Milliseconds(),
+	})
+	tflog.SubsystemTrace(ctx, apiLogSubsystem, "RMON API response body", map[string]interface{}{
+		"body": redactBody(respBody),
+	})
+
+	return &apiResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
+	}, nil
+}
+
+// newRequest builds a request carrying the headers shared by every call to
+// RMON, including the user-configured custom headers.
+func (c *Client) newRequest(ctx context.Context, method, reqURL string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	return req, nil
+}
+
+func (c *Client) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isTokenExpired reports whether the response means the bearer token must be
+// refreshed. RMON answers 401 for a missing or expired token and 403 with an
+// explanatory body for a token it can no longer verify.
+func isTokenExpired(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		lower := strings.ToLower(string(body))
+		for _, marker := range tokenExpiredMarkers {
+			if strings.Contains(lower, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,264 @@
+package rmonapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-rmon/rmontest"
+)
+
+// newTestClient logs in to srv with the default credentials. setup may adjust
+// the configuration before the client is built.
+func newTestClient(t *testing.T, srv *rmontest.Server, setup func(cfg *Config)) *Client {
+	t.Helper()
+
+	cfg := Config{
+		BaseURL:  srv.URL,
+		Login:    rmontest.DefaultLogin,
+		Password: rmontest.DefaultPassword,
+	}
+	if setup != nil {
+		setup(&cfg)
+	}
+
+	client, err := NewClient(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	return client
+}
+
+func newTestServer(t *testing.T) *rmontest.Server {
+	t.Helper()
+
+	srv := rmontest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func countRequests(srv *rmontest.Server, method, path string) int {
+	count := 0
+	for _, req := range srv.Requests() {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestClientReauthenticatesOnceForParallelRequests(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, nil)
+
+	if got := countRequests(srv, "POST", apiPrefix+"/login"); got != 1 {
+		t.Fatalf("logins after NewClient = %d, want 1", got)
+	}
+
+	srv.ExpireTokens()
+
+	const parallel = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, parallel)
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Groups.List(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Groups.List: %s", err)
+	}
+	if got := countRequests(srv, "POST", apiPrefix+"/login"); got != 2 {
+		t.Errorf("logins after the token expired = %d, want 2", got)
+	}
+}
+
+func TestClientAPITokenDoesNotLogIn(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, func(cfg *Config) {
+		cfg.Login = ""
+		cfg.Password = ""
+		cfg.APIToken = rmontest.DefaultAPIToken
+	})
+
+	if _, err := client.Groups.List(context.Background()); err != nil {
+		t.Fatalf("Groups.List: %s", err)
+	}
+
+	// Expiring the login tokens must not affect the static token.
+	srv.ExpireTokens()
+	if _, err := client.Groups.List(context.Background()); err != nil {
+		t.Fatalf("Groups.List after ExpireTokens: %s", err)
+	}
+
+	if got := countRequests(srv, "POST", apiPrefix+"/login"); got != 0 {
+		t.Errorf("logins = %d, want 0", got)
+	}
+}
+
+func TestClientRejectedAPITokenIsFinal(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, func(cfg *Config) {
+		cfg.Login = ""
+		cfg.Password = ""
+		cfg.APIToken = "not-a-valid-token"
+	})
+
+	_, err := client.Groups.List(context.Background())
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Groups.List error = %v, want 401", err)
+	}
+	if got := countRequests(srv, "POST", apiPrefix+"/login"); got != 0 {
+		t.Errorf("logins = %d, want 0", got)
+	}
+}
+
+func TestIsTokenExpired(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       bool
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"error": "Missing Authorization Header"}`, true},
+		{"forbidden with expired token", http.StatusForbidden, `{"error": "Token has EXPIRED"}`, true},
+		{"forbidden with invalid token", http.StatusForbidden, `{"error": "Invalid token"}`, true},
+		{"plain forbidden", http.StatusForbidden, `{"error": "You do not have permission"}`, false},
+		{"not found", http.StatusNotFound, `{"error": "expired"}`, false},
+		{"ok", http.StatusOK, ``, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTokenExpired(tt.statusCode, []byte(tt.body)); got != tt.want {
+				t.Errorf("isTokenExpired(%d, %q) = %t, want %t", tt.statusCode, tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+// newHangingServer answers nothing until the request is abandoned by the
+// client. It returns the number of requests received so far.
+func newHangingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	return srv, &hits
+}
+
+func newHangingClient(t *testing.T, srv *httptest.Server, timeout time.Duration, retry RetryPolicy) *Client {
+	t.Helper()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:        srv.URL,
+		APIToken:       "token",
+		RequestTimeout: timeout,
+		Retry:          retry,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	return client
+}
+
+func TestClientRequestTimeoutAbortsSlowCall(t *testing.T) {
+	srv, hits := newHangingServer(t)
+	client := newHangingClient(t, srv, 100*time.Millisecond, RetryPolicy{})
+
+	start := time.Now()
+	_, err := client.ServerVersion(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ServerVersion error = %v, want a deadline exceeded error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("ServerVersion returned after %s, want about 100ms", elapsed)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
+
+func TestClientRetriesAfterRequestTimeout(t *testing.T) {
+	srv, hits := newHangingServer(t)
+	client := newHangingClient(t, srv, 50*time.Millisecond, RetryPolicy{
+		MaxRetries: 2,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	})
+
+	if _, err := client.ServerVersion(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ServerVersion error = %v, want a deadline exceeded error", err)
+	}
+	// Each attempt gets its own timeout, so a GET is retried.
+	if got := hits.Load(); got != 3 {
+		t.Errorf("%d requests, want 3", got)
+	}
+}
+
+func TestClientCancelStopsBackoff(t *testing.T) {
+	srv, hits := newHangingServer(t)
+	client := newHangingClient(t, srv, 50*time.Millisecond, RetryPolicy{
+		MaxRetries: 5,
+		MinBackoff: 10 * time.Second,
+		MaxBackoff: 10 * time.Second,
+	})
+
+	// The first attempt times out after 50ms, the cancellation then lands
+	// during the long backoff.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.ServerVersion(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ServerVersion error = %v, want the context error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("ServerVersion returned after %s, want it to stop at the cancellation", elapsed)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
+
+func TestClientCancelStopsRetries(t *testing.T) {
+	srv, hits := newHangingServer(t)
+	client := newHangingClient(t, srv, 0, RetryPolicy{
+		MaxRetries: 5,
+		MinBackoff: 10 * time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	if _, err := client.ServerVersion(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ServerVersion error = %v, want context.Canceled", err)
+	}
+	// A cancelled caller is final even though the error looks transient.
+	if got := hits.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
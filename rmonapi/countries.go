@@ -0,0 +1,53 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+type Country struct {
+	// ID is only set on countries returned by List.
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     Bool   `json:"enabled"`
+	Shared      Bool   `json:"shared"`
+	GroupID     int    `json:"group_id"`
+}
+
+type CountryService struct {
+	client *Client
+}
+
+func (s *CountryService) Create(ctx context.Context, country *Country) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/rmon/country", country)
+}
+
+func (s *CountryService) Get(ctx context.Context, id int) (*Country, error) {
+	var country Country
+	if err := s.client.Do(ctx, "GET", countryPath(id), nil, &country); err != nil {
+		return nil, err
+	}
+	return &country, nil
+}
+
+// List returns every country visible to the user.
+func (s *CountryService) List(ctx context.Context) ([]Country, error) {
+	var countries []Country
+	if err := s.client.Do(ctx, "GET", apiPrefix+"/rmon/country", nil, &countries); err != nil {
+		return nil, err
+	}
+	return countries, nil
+}
+
+func (s *CountryService) Update(ctx context.Context, id int, country *Country) error {
+	return s.client.Do(ctx, "PUT", countryPath(id), country, nil)
+}
+
+func (s *CountryService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", countryPath(id), nil, nil)
+}
+
+func countryPath(id int) string {
+	return fmt.Sprintf("%s/rmon/country/%d", apiPrefix, id)
+}
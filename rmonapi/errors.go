@@ -0,0 +1,99 @@
+package rmonapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// requestIDHeaders are the headers RMON and its nginx front end use to tag a
+// request, checked in order.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+}
+
+// Error is returned for every non-2xx response from RMON.
+type Error struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	// Message is the error reported by RMON in the response body, if any.
+	Message   string
+	RequestID string
+	Body      []byte
+}
+
+func newError(method, endpoint string, resp *apiResponse) *Error {
+	apiErr := &Error{
+		Method:     method,
+		Endpoint:   endpoint,
+		StatusCode: resp.StatusCode,
+		Message:    parseErrorMessage(resp.Body),
+		Body:       resp.Body,
+	}
+
+	for _, header := range requestIDHeaders {
+		if id := resp.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s: unexpected status code: %d", e.Method, e.Endpoint, e.StatusCode)
+	if e.Message != "" {
+		fmt.Fprintf(&b, ", error: %s", e.Message)
+	} else if len(e.Body) > 0 {
+		fmt.Fprintf(&b, ", response: %s", e.Body)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID: %s)", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// parseErrorMessage extracts the error text from an RMON error body such as
+// {"status": "failed", "error": "..."}.
+func parseErrorMessage(body []byte) string {
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return ""
+	}
+
+	for _, key := range []string{"error", "message", "detail"} {
+		if msg, ok := result[key].(string); ok && msg != "" {
+			return msg
+		}
+	}
+
+	return ""
+}
+
+// IsNotFound reports whether err is an RMON 404 response.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// notFoundError is returned when RMON answers an existing endpoint with an
+// empty result instead of a 404, as the SSH credential endpoint does.
+func notFoundError(method, endpoint string) *Error {
+	return &Error{
+		Method:     method,
+		Endpoint:   endpoint,
+		StatusCode: http.StatusNotFound,
+		Message:    "object not found",
+	}
+}
@@ -0,0 +1,50 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// Group is a user-defined pool of servers.
+type Group struct {
+	// ID is only populated by List.
+	ID          int    `json:"group_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type GroupService struct {
+	client *Client
+}
+
+func (s *GroupService) Create(ctx context.Context, group *Group) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/group", group)
+}
+
+func (s *GroupService) Get(ctx context.Context, id int) (*Group, error) {
+	var group Group
+	if err := s.client.Do(ctx, "GET", groupPath(id), nil, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (s *GroupService) List(ctx context.Context) ([]Group, error) {
+	var groups []Group
+	if err := s.client.Do(ctx, "GET", apiPrefix+"/groups", nil, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (s *GroupService) Update(ctx context.Context, id int, group *Group) error {
+	return s.client.Do(ctx, "PUT", groupPath(id), group, nil)
+}
+
+func (s *GroupService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", groupPath(id), nil, nil)
+}
+
+func groupPath(id int) string {
+	return fmt.Sprintf("%s/group/%d", apiPrefix, id)
+}
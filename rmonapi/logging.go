@@ -0,0 +1,99 @@
+package rmonapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	apiLogSubsystem = "rmon_api"
+	redactedValue   = "***"
+)
+
+// secretFields are masked in log fields and in logged JSON bodies. Keys that
+// end with "_<field>" (e.g. "api_token") are masked as well.
+var secretFields = []string{
+	"password",
+	"token",
+	"access_token",
+	"private_key",
+	"passphrase",
+	"secret",
+	"webhook_url",
+	"headers",
+}
+
+type correlationIDKey struct{}
+
+// withAPILogging prepares ctx for logging RMON API traffic under the
+// "rmon_api" subsystem. The level follows TF_LOG_PROVIDER and can be
+// overridden with TF_LOG_PROVIDER_RMON_API. Every log entry carries a
+// correlation ID that is also sent to RMON in the X-Request-Id header.
+func withAPILogging(ctx context.Context) context.Context {
+	correlationID, err := uuid.GenerateUUID()
+	if err != nil {
+		correlationID = "unknown"
+	}
+
+	ctx = tflog.NewSubsystem(ctx, apiLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", "RMON_API"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, apiLogSubsystem, secretFields...)
+	ctx = tflog.SubsystemSetField(ctx, apiLogSubsystem, "correlation_id", correlationID)
+
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+func correlationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range secretFields {
+		if key == field || strings.HasSuffix(key, "_"+field) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody returns a loggable copy of a JSON body with every secret value
+// replaced. Bodies that are not JSON are returned unchanged.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSecretField(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
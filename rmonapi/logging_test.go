@@ -0,0 +1,103 @@
+package rmonapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestIsSecretField(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"Password", true},
+		{"token", true},
+		{"access_token", true},
+		{"api_token", true},
+		{"hmac_secret", true},
+		{"ssh_private_key", true},
+		{"webhook_url", true},
+		{"headers", true},
+		{"name", false},
+		{"url", false},
+		{"tokens", false},
+		{"passwordless", false},
+		{"secret_name", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSecretField(tt.key); got != tt.want {
+			t.Errorf("isSecretField(%q) = %t, want %t", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "flat object",
+			body: `{"login": "admin", "password": "hunter2"}`,
+			want: `{"login": "admin", "password": "***"}`,
+		},
+		{
+			name: "suffix matches",
+			body: `{"api_token": "abc", "hmac_secret": "def", "channel": "alerts"}`,
+			want: `{"api_token": "***", "hmac_secret": "***", "channel": "alerts"}`,
+		},
+		{
+			name: "nested object",
+			body: `{"name": "smtp", "auth": {"username": "bot", "password": "hunter2"}}`,
+			want: `{"name": "smtp", "auth": {"username": "bot", "password": "***"}}`,
+		},
+		{
+			name: "secret object is masked as a whole",
+			body: `{"headers": {"Authorization": "Bearer abc"}}`,
+			want: `{"headers": "***"}`,
+		},
+		{
+			name: "array of objects",
+			body: `[{"id": 1, "token": "a"}, {"id": 2, "token": "b"}]`,
+			want: `[{"id": 1, "token": "***"}, {"id": 2, "token": "***"}]`,
+		},
+		{
+			name: "array inside an object",
+			body: `{"channels": [{"receiver": "slack", "webhook_url": "https://hooks"}]}`,
+			want: `{"channels": [{"receiver": "slack", "webhook_url": "***"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, want interface{}
+			if err := json.Unmarshal([]byte(redactBody([]byte(tt.body))), &got); err != nil {
+				t.Fatalf("redactBody returned invalid JSON: %s", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("redactBody(%s) = %v, want %v", tt.body, got, want)
+			}
+		})
+	}
+}
+
+func TestRedactBodyNotJSON(t *testing.T) {
+	tests := []string{
+		"",
+		"<html><body>502 Bad Gateway</body></html>",
+		`{"password": "truncated`,
+	}
+
+	for _, body := range tests {
+		if got := redactBody([]byte(body)); got != body {
+			t.Errorf("redactBody(%q) = %q, want it unchanged", body, got)
+		}
+	}
+}
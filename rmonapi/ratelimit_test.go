@@ -0,0 +1,115 @@
+package rmonapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterRate(t *testing.T) {
+	limiter := newRateLimiter(20)
+
+	// The bucket starts full, so the first burst of requests passes at once
+	// and the next ten are spread at 20 per second.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 450*time.Millisecond || elapsed > 1500*time.Millisecond {
+		t.Errorf("30 requests at 20 req/s with a burst of 20 took %s, want about 500ms", elapsed)
+	}
+}
+
+func TestRateLimiterBelowOnePerSecond(t *testing.T) {
+	limiter := newRateLimiter(0.5)
+	if limiter.burst != 1 {
+		t.Fatalf("burst = %v, want 1", limiter.burst)
+	}
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next token takes two seconds, more than the context allows.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// Pretend the two seconds have passed.
+	limiter.mu.Lock()
+	limiter.last = limiter.last.Add(-2 * time.Second)
+	limiter.mu.Unlock()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("Wait after refill = %v", err)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		if limiter := newRateLimiter(rate); limiter != nil {
+			t.Errorf("newRateLimiter(%v) = %+v, want nil", rate, limiter)
+		}
+	}
+
+	var limiter *rateLimiter
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Errorf("nil limiter Wait = %v", err)
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	sem := newSemaphore(2)
+
+	for i := 0; i < 2; i++ {
+		if err := sem.Acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A third request waits until its context is cancelled and must not
+	// hold a slot afterwards.
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- sem.Acquire(ctx) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Acquire on a full semaphore returned %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Acquire = %v, want %v", err, context.Canceled)
+	}
+	if len(sem) != 2 {
+		t.Fatalf("slots in use after the cancelled Acquire = %d, want 2", len(sem))
+	}
+
+	sem.Release()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sem.Acquire(ctx); err != nil {
+		t.Fatalf("Acquire after Release = %v", err)
+	}
+}
+
+func TestSemaphoreDisabled(t *testing.T) {
+	sem := newSemaphore(0)
+	for i := 0; i < 100; i++ {
+		if err := sem.Acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sem.Release()
+}
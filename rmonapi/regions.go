@@ -0,0 +1,54 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+type Region struct {
+	// ID is only set on regions returned by List.
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     Bool   `json:"enabled"`
+	Shared      Bool   `json:"shared"`
+	CountryID   int    `json:"country_id"`
+	GroupID     int    `json:"group_id"`
+}
+
+type RegionService struct {
+	client *Client
+}
+
+func (s *RegionService) Create(ctx context.Context, region *Region) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/rmon/region", region)
+}
+
+func (s *RegionService) Get(ctx context.Context, id int) (*Region, error) {
+	var region Region
+	if err := s.client.Do(ctx, "GET", regionPath(id), nil, &region); err != nil {
+		return nil, err
+	}
+	return &region, nil
+}
+
+// List returns every region visible to the user.
+func (s *RegionService) List(ctx context.Context) ([]Region, error) {
+	var regions []Region
+	if err := s.client.Do(ctx, "GET", apiPrefix+"/rmon/region", nil, &regions); err != nil {
+		return nil, err
+	}
+	return regions, nil
+}
+
+func (s *RegionService) Update(ctx context.Context, id int, region *Region) error {
+	return s.client.Do(ctx, "PUT", regionPath(id), region, nil)
+}
+
+func (s *RegionService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", regionPath(id), nil, nil)
+}
+
+func regionPath(id int) string {
+	return fmt.Sprintf("%s/rmon/region/%d", apiPrefix, id)
+}
@@ -0,0 +1,153 @@
+package rmonapi
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultMaxRetries = 3
+	DefaultMinBackoff = 1 * time.Second
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// DefaultRetryableStatusCodes are the responses the RMON nginx front end
+// produces while the application is restarting or overloaded.
+var DefaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy controls how the client retries transient failures.
+type RetryPolicy struct {
+	// MaxRetries is the number of additional attempts after the first one.
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RetryableStatusCodes are retried for idempotent methods only.
+	RetryableStatusCodes []int
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:           DefaultMaxRetries,
+		MinBackoff:           DefaultMinBackoff,
+		MaxBackoff:           DefaultMaxBackoff,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// shouldRetryError reports whether a transport error may be retried. POST and
+// PATCH are not idempotent, so they are only retried when the connection was
+// never established and the server cannot have seen the request.
+func (p RetryPolicy) shouldRetryError(method string, err error) bool {
+	if isConnectError(err) {
+		return true
+	}
+	if !isIdempotent(method) {
+		return false
+	}
+	return isRetryableNetworkError(err)
+}
+
+func (p RetryPolicy) shouldRetryStatus(method string, statusCode int) bool {
+	if !isIdempotent(method) {
+		return false
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry attempt (starting at 0).
+// A Retry-After header takes precedence over the computed delay, but neither
+// may exceed MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, header http.Header) time.Duration {
+	if wait, ok := parseRetryAfter(header); ok {
+		if wait > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return wait
+	}
+
+	wait := p.MinBackoff
+	for i := 0; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Equal jitter: keep half of the delay and randomize the rest so that
+	// parallel resources do not retry in lockstep.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isConnectError reports whether err happened before a connection to the
+// server was established (DNS lookup, dial or proxy connect).
+func isConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial" || opErr.Op == "proxyconnect"
+	}
+	return false
+}
+
+func isRetryableNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package rmonapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"terraform-provider-rmon/rmontest"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 8 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 500 * time.Millisecond, time.Second},
+		{1, time.Second, 2 * time.Second},
+		{2, 2 * time.Second, 4 * time.Second},
+		{3, 4 * time.Second, 8 * time.Second},
+		// Capped at MaxBackoff from here on.
+		{4, 4 * time.Second, 8 * time.Second},
+		{30, 4 * time.Second, 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempt), func(t *testing.T) {
+			// The jitter is random, so sample it a few times.
+			for i := 0; i < 100; i++ {
+				got := policy.backoff(tt.attempt, nil)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoffRetryAfter(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 30 * time.Second}
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{"seconds", "7", 7 * time.Second, 7 * time.Second},
+		{"seconds above the cap", "120", 30 * time.Second, 30 * time.Second},
+		{"HTTP date", time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 8 * time.Second, 10 * time.Second},
+		{"HTTP date in the past", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+		{"invalid falls back to the backoff", "soon", 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Retry-After", tt.retryAfter)
+
+			got := policy.backoff(0, header)
+			if got < tt.min || got > tt.max {
+				t.Errorf("backoff with Retry-After %q = %s, want between %s and %s", tt.retryAfter, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestShouldRetryStatus(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	tests := []struct {
+		method     string
+		statusCode int
+		want       bool
+	}{
+		{http.MethodGet, http.StatusServiceUnavailable, true},
+		{http.MethodPut, http.StatusTooManyRequests, true},
+		{http.MethodDelete, http.StatusBadGateway, true},
+		{http.MethodGet, http.StatusInternalServerError, false},
+		{http.MethodGet, http.StatusNotFound, false},
+		{http.MethodPost, http.StatusServiceUnavailable, false},
+		{http.MethodPost, http.StatusTooManyRequests, false},
+		{http.MethodPatch, http.StatusGatewayTimeout, false},
+	}
+
+	for _, tt := range tests {
+		if got := policy.shouldRetryStatus(tt.method, tt.statusCode); got != tt.want {
+			t.Errorf("shouldRetryStatus(%s, %d) = %t, want %t", tt.method, tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestShouldRetryError(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	dialErr := &url.Error{Op: "Post", URL: "https://rmon", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	dnsErr := &url.Error{Op: "Post", URL: "https://rmon", Err: &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "rmon"}}}
+	proxyErr := &net.OpError{Op: "proxyconnect", Net: "tcp", Err: syscall.ECONNREFUSED}
+	resetErr := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		want   bool
+	}{
+		{"POST dial error", http.MethodPost, dialErr, true},
+		{"POST DNS error", http.MethodPost, dnsErr, true},
+		{"POST proxy connect error", http.MethodPost, proxyErr, true},
+		{"POST connection reset", http.MethodPost, resetErr, false},
+		{"POST EOF", http.MethodPost, io.ErrUnexpectedEOF, false},
+		{"POST timeout", http.MethodPost, timeoutError{}, false},
+		{"PATCH connection reset", http.MethodPatch, resetErr, false},
+		{"GET dial error", http.MethodGet, dialErr, true},
+		{"GET connection reset", http.MethodGet, resetErr, true},
+		{"GET EOF", http.MethodGet, io.EOF, true},
+		{"PUT timeout", http.MethodPut, timeoutError{}, true},
+		{"GET other error", http.MethodGet, errors.New("x509: certificate signed by unknown authority"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.shouldRetryError(tt.method, tt.err); got != tt.want {
+				t.Errorf("shouldRetryError(%s, %v) = %t, want %t", tt.method, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// fastRetries retries quickly enough for tests while still honoring
+// Retry-After.
+func fastRetries(cfg *Config) {
+	cfg.Retry = RetryPolicy{
+		MaxRetries:           3,
+		MinBackoff:           time.Millisecond,
+		MaxBackoff:           2 * time.Second,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, fastRetries)
+
+	srv.InjectFault(rmontest.Fault{Method: "GET", Path: apiPrefix + "/groups", StatusCode: http.StatusBadGateway, Times: 2})
+
+	if _, err := client.Groups.List(context.Background()); err != nil {
+		t.Fatalf("Groups.List: %s", err)
+	}
+	if got := countRequests(srv, "GET", apiPrefix+"/groups"); got != 3 {
+		t.Errorf("GET /groups requests = %d, want 3", got)
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, fastRetries)
+
+	srv.InjectFault(rmontest.Fault{Method: "GET", Path: apiPrefix + "/groups", StatusCode: http.StatusServiceUnavailable})
+
+	_, err := client.Groups.List(context.Background())
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Groups.List error = %v, want 503", err)
+	}
+	if got := countRequests(srv, "GET", apiPrefix+"/groups"); got != 4 {
+		t.Errorf("GET /groups requests = %d, want 4", got)
+	}
+}
+
+func TestClientHonorsRetryAfter(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, fastRetries)
+
+	srv.InjectFault(rmontest.Fault{Method: "GET", Path: apiPrefix + "/groups", StatusCode: http.StatusTooManyRequests, RetryAfter: 1, Times: 1})
+
+	start := time.Now()
+	if _, err := client.Groups.List(context.Background()); err != nil {
+		t.Fatalf("Groups.List: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s from Retry-After", elapsed)
+	}
+}
+
+func TestClientDoesNotRetryPostOnServerErrors(t *testing.T) {
+	srv := newTestServer(t)
+	client := newTestClient(t, srv, fastRetries)
+
+	srv.InjectFault(rmontest.Fault{Method: "POST", Path: apiPrefix + "/group", StatusCode: http.StatusServiceUnavailable, Times: 1})
+
+	if _, err := client.Groups.Create(context.Background(), &Group{Name: "test"}); err == nil {
+		t.Fatal("Groups.Create succeeded, want the 503")
+	}
+	if got := countRequests(srv, "POST", apiPrefix+"/group"); got != 1 {
+		t.Errorf("POST /group requests = %d, want 1", got)
+	}
+	if got := srv.Count(rmontest.KindGroup); got != 1 {
+		t.Errorf("groups = %d, want only the Default group", got)
+	}
+}
+
+// TestClientRetriesLoginUntilReachable starts the client while nothing is
+// listening on its address yet, which fails the login with a dial error.
+func TestClientRetriesLoginUntilReachable(t *testing.T) {
+	srv := newTestServer(t)
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reserve a free port, then release it so that the first dial fails.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	proxy := &http.Server{Handler: httputil.NewSingleHostReverseProxy(target)}
+	t.Cleanup(func() { proxy.Close() })
+	time.AfterFunc(200*time.Millisecond, func() {
+		if listener, err := net.Listen("tcp", addr); err == nil {
+			go proxy.Serve(listener)
+		}
+	})
+
+	newTestClient(t, srv, func(cfg *Config) {
+		cfg.BaseURL = "http://" + addr
+		cfg.Retry = RetryPolicy{MaxRetries: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}
+	})
+
+	if got := countRequests(srv, "POST", apiPrefix+"/login"); got != 1 {
+		t.Errorf("logins that reached RMON = %d, want 1", got)
+	}
+}
@@ -0,0 +1,55 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+type Server struct {
+	// ID is only set on servers returned by List.
+	ID          int    `json:"id,omitempty"`
+	CredID      int    `json:"cred_id"`
+	Description string `json:"description"`
+	Enabled     Bool   `json:"enabled"`
+	GroupID     int    `json:"group_id"`
+	Hostname    string `json:"hostname"`
+	IP          string `json:"ip"`
+	Port        int    `json:"port"`
+}
+
+type ServerService struct {
+	client *Client
+}
+
+func (s *ServerService) Create(ctx context.Context, server *Server) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/server", server)
+}
+
+func (s *ServerService) Get(ctx context.Context, id int) (*Server, error) {
+	var server Server
+	if err := s.client.Do(ctx, "GET", serverPath(id), nil, &server); err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+// List returns every server registered in RMON.
+func (s *ServerService) List(ctx context.Context) ([]Server, error) {
+	var servers []Server
+	if err := s.client.Do(ctx, "GET", apiPrefix+"/server", nil, &servers); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+func (s *ServerService) Update(ctx context.Context, id int, server *Server) error {
+	return s.client.Do(ctx, "PUT", serverPath(id), server, nil)
+}
+
+func (s *ServerService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", serverPath(id), nil, nil)
+}
+
+func serverPath(id int) string {
+	return fmt.Sprintf("%s/server/%d", apiPrefix, id)
+}
@@ -0,0 +1,99 @@
+package rmonapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// SSHCredential holds the login RMON uses to reach servers. The private key
+// is write-only and set through UpdateKey.
+type SSHCredential struct {
+	GroupID    int    `json:"group_id"`
+	KeyEnabled Bool   `json:"key_enabled"`
+	Name       string `json:"name"`
+	Username   string `json:"username"`
+	Password   string `json:"password"`
+	Shared     Bool   `json:"shared"`
+	PrivateKey string `json:"private_key,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// SSHKey is a partial update of the key material of SSH credentials. Nil
+// fields are left unchanged.
+type SSHKey struct {
+	PrivateKey *string `json:"private_key,omitempty"`
+	Passphrase *string `json:"passphrase,omitempty"`
+}
+
+type SSHCredentialService struct {
+	client *Client
+}
+
+// Create returns the ID of the new credentials even when RMON reports a
+// failure status, so that callers can clean them up.
+func (s *SSHCredentialService) Create(ctx context.Context, cred *SSHCredential) (int, error) {
+	path := apiPrefix + "/server/cred"
+
+	var result createResponse
+	if err := s.client.Do(ctx, "POST", path, cred, &result); err != nil {
+		return 0, err
+	}
+
+	if result.ID == 0 {
+		return 0, fmt.Errorf("POST %s: unable to find ID in response", path)
+	}
+	if result.Status != "Ok" {
+		return int(result.ID), fmt.Errorf("POST %s: unexpected status in response: %q", path, result.Status)
+	}
+	return int(result.ID), nil
+}
+
+// Get returns a NotFound error when RMON answers with an empty list.
+func (s *SSHCredentialService) Get(ctx context.Context, id int) (*SSHCredential, error) {
+	var creds []SSHCredential
+	if err := s.client.Do(ctx, "GET", sshCredentialPath(id), nil, &creds); err != nil {
+		return nil, err
+	}
+
+	if len(creds) == 0 {
+		return nil, notFoundError("GET", sshCredentialPath(id))
+	}
+	return &creds[0], nil
+}
+
+// Update accepts both response shapes RMON uses for this endpoint: a status
+// object or the list of updated credentials.
+func (s *SSHCredentialService) Update(ctx context.Context, id int, cred *SSHCredential) error {
+	var result json.RawMessage
+	if err := s.client.Do(ctx, "PUT", sshCredentialPath(id), cred, &result); err != nil {
+		return err
+	}
+
+	var status createResponse
+	if err := json.Unmarshal(result, &status); err == nil {
+		if status.Status != "Ok" {
+			return fmt.Errorf("PUT %s: unexpected status in response: %q", sshCredentialPath(id), status.Status)
+		}
+		return nil
+	}
+
+	var creds []json.RawMessage
+	if err := json.Unmarshal(result, &creds); err != nil || len(creds) == 0 {
+		return fmt.Errorf("PUT %s: unexpected response format: %s", sshCredentialPath(id), result)
+	}
+	return nil
+}
+
+func (s *SSHCredentialService) UpdateKey(ctx context.Context, id int, key *SSHKey) error {
+	return s.client.Do(ctx, "PATCH", sshCredentialPath(id), key, nil)
+}
+
+func (s *SSHCredentialService) Delete(ctx context.Context, id int) error {
+	var result []json.RawMessage
+	return s.client.Do(ctx, "DELETE", sshCredentialPath(id), nil, &result)
+}
+
+func sshCredentialPath(id int) string {
+	return fmt.Sprintf("%s/server/cred/%d", apiPrefix, id)
+}
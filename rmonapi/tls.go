@@ -0,0 +1,87 @@
+package rmonapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TLSConfig describes how the client verifies RMON and authenticates itself
+// at the TLS layer. The zero value uses the system trust store.
+type TLSConfig struct {
+	// CACertFile and CACertPEM are both added to the system roots.
+	CACertFile string
+	CACertPEM  string
+	// ClientCert and ClientKey hold either PEM content or a path to a PEM file.
+	ClientCert         string
+	ClientKey          string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+func (t TLSConfig) build() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CACertFile != "" || t.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if t.CACertFile != "" {
+			pem, err := os.ReadFile(t.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid PEM certificates found in %s", t.CACertFile)
+			}
+		}
+
+		if t.CACertPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(t.CACertPEM)) {
+				return nil, fmt.Errorf("no valid PEM certificates found in CA certificate PEM")
+			}
+		}
+
+		config.RootCAs = pool
+	}
+
+	if t.ClientCert != "" || t.ClientKey != "" {
+		if t.ClientCert == "" || t.ClientKey == "" {
+			return nil, fmt.Errorf("client certificate and client key must be set together")
+		}
+
+		certPEM, err := readPEM(t.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(t.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// readPEM returns value itself when it already contains PEM data, otherwise
+// it treats value as a path and reads the file.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,303 @@
+package rmonapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCA issues certificates signed by a throwaway certificate authority.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rmon test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %s", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %s", err)
+	}
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a PEM certificate and key for localhost with the given usage.
+func (ca *testCA) issue(t *testing.T, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key := newTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	return key
+}
+
+func writeTestFile(t *testing.T, name string, content []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	return path
+}
+
+func TestTLSConfigBuild(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, _ := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, x509.ExtKeyUsageClientAuth)
+	_, otherKey := ca.issue(t, x509.ExtKeyUsageClientAuth)
+
+	caFile := writeTestFile(t, "ca.pem", ca.pem)
+	certFile := writeTestFile(t, "client.pem", clientCert)
+	keyFile := writeTestFile(t, "client-key.pem", clientKey)
+	garbageFile := writeTestFile(t, "garbage.pem", []byte("not a certificate"))
+	missingFile := filepath.Join(t.TempDir(), "missing.pem")
+	badPEM := "-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydGlmaWNhdGU=\n-----END CERTIFICATE-----\n"
+
+	tests := []struct {
+		name string
+		tls  TLSConfig
+		// trustsServer reports whether the RootCAs must accept serverCert.
+		trustsServer bool
+		clientCerts  int
+		wantErr      string
+	}{
+		{name: "system roots"},
+		{name: "CA file", tls: TLSConfig{CACertFile: caFile}, trustsServer: true},
+		{name: "CA PEM", tls: TLSConfig{CACertPEM: string(ca.pem)}, trustsServer: true},
+		{name: "CA file and PEM", tls: TLSConfig{CACertFile: caFile, CACertPEM: string(ca.pem)}, trustsServer: true},
+		{name: "missing CA file", tls: TLSConfig{CACertFile: missingFile}, wantErr: "unable to read CA certificate file"},
+		{name: "CA file without PEM", tls: TLSConfig{CACertFile: garbageFile}, wantErr: "no valid PEM certificates found in " + garbageFile},
+		{name: "invalid CA PEM", tls: TLSConfig{CACertPEM: badPEM}, wantErr: "no valid PEM certificates found in CA certificate PEM"},
+		{name: "client PEM", tls: TLSConfig{ClientCert: string(clientCert), ClientKey: string(clientKey)}, clientCerts: 1},
+		{name: "client files", tls: TLSConfig{ClientCert: certFile, ClientKey: keyFile}, clientCerts: 1},
+		{name: "client PEM and key file", tls: TLSConfig{ClientCert: string(clientCert), ClientKey: keyFile}, clientCerts: 1},
+		{name: "client cert only", tls: TLSConfig{ClientCert: certFile}, wantErr: "must be set together"},
+		{name: "client key only", tls: TLSConfig{ClientKey: keyFile}, wantErr: "must be set together"},
+		{name: "missing client cert", tls: TLSConfig{ClientCert: missingFile, ClientKey: keyFile}, wantErr: "unable to read client certificate"},
+		{name: "missing client key", tls: TLSConfig{ClientCert: certFile, ClientKey: missingFile}, wantErr: "unable to read client key"},
+		{name: "mismatched client key", tls: TLSConfig{ClientCert: certFile, ClientKey: string(otherKey)}, wantErr: "invalid client certificate or key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := tt.tls.build()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("build() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("build(): %s", err)
+			}
+
+			if config.MinVersion != tls.VersionTLS12 {
+				t.Errorf("MinVersion = %x, want TLS 1.2", config.MinVersion)
+			}
+			if tt.trustsServer {
+				block, _ := pem.Decode(serverCert)
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					t.Fatalf("ParseCertificate: %s", err)
+				}
+				if _, err := cert.Verify(x509.VerifyOptions{Roots: config.RootCAs}); err != nil {
+					t.Errorf("server certificate not trusted: %s", err)
+				}
+			} else if config.RootCAs != nil {
+				t.Errorf("RootCAs is set, want the system trust store")
+			}
+			if got := len(config.Certificates); got != tt.clientCerts {
+				t.Errorf("%d client certificates, want %d", got, tt.clientCerts)
+			}
+		})
+	}
+}
+
+func TestTLSConfigBuildPassesServerSettings(t *testing.T) {
+	config, err := TLSConfig{ServerName: "rmon.internal", InsecureSkipVerify: true}.build()
+	if err != nil {
+		t.Fatalf("build(): %s", err)
+	}
+	if config.ServerName != "rmon.internal" {
+		t.Errorf("ServerName = %q, want rmon.internal", config.ServerName)
+	}
+	if !config.InsecureSkipVerify {
+		t.Errorf("InsecureSkipVerify = false, want true")
+	}
+}
+
+func TestReadPEM(t *testing.T) {
+	content := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	file := writeTestFile(t, "cert.pem", []byte(content))
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "inline PEM", value: content, want: content},
+		{name: "path", value: file, want: content},
+		{name: "missing path", value: filepath.Join(t.TempDir(), "missing.pem"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPEM(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("readPEM(%q) returned no error", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readPEM(%q): %s", tt.value, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("readPEM(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTLSTestServer serves /version over TLS with a certificate signed by ca.
+// clientCAs, when set, makes the server require a client certificate.
+func newTLSTestServer(t *testing.T, ca *testCA, clientCAs *x509.CertPool) *httptest.Server {
+	t.Helper()
+
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("X509KeyPair: %s", err)
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"version": "1.2.0"}`))
+	}))
+	// Rejected handshakes are expected, keep them out of the test output.
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	if clientCAs != nil {
+		srv.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+		srv.TLS.ClientCAs = clientCAs
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTLSTestClient(t *testing.T, srv *httptest.Server, tlsConfig TLSConfig) *Client {
+	t.Helper()
+
+	client, err := NewClient(context.Background(), Config{
+		BaseURL:  srv.URL,
+		APIToken: "token",
+		TLS:      tlsConfig,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	return client
+}
+
+func TestClientTrustsCustomCA(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newTLSTestServer(t, ca, nil)
+
+	client := newTLSTestClient(t, srv, TLSConfig{CACertPEM: string(ca.pem)})
+	if _, err := client.ServerVersion(context.Background()); err != nil {
+		t.Fatalf("ServerVersion with the CA: %s", err)
+	}
+
+	client = newTLSTestClient(t, srv, TLSConfig{})
+	if _, err := client.ServerVersion(context.Background()); err == nil {
+		t.Fatalf("ServerVersion without the CA succeeded, want a certificate error")
+	}
+}
+
+func TestClientMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	srv := newTLSTestServer(t, ca, clientCAs)
+
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageClientAuth)
+	client := newTLSTestClient(t, srv, TLSConfig{
+		CACertPEM:  string(ca.pem),
+		ClientCert: string(certPEM),
+		ClientKey:  writeTestFile(t, "client-key.pem", keyPEM),
+	})
+	if _, err := client.ServerVersion(context.Background()); err != nil {
+		t.Fatalf("ServerVersion with a client certificate: %s", err)
+	}
+
+	client = newTLSTestClient(t, srv, TLSConfig{CACertPEM: string(ca.pem)})
+	if _, err := client.ServerVersion(context.Background()); err == nil {
+		t.Fatalf("ServerVersion without a client certificate succeeded, want a handshake error")
+	}
+}
@@ -0,0 +1,72 @@
+package rmonapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const apiPrefix = "/api/v1.0"
+
+// Bool is a boolean that RMON stores as 0 or 1. Decoding also accepts JSON
+// booleans and null, since not every endpoint is consistent about it.
+type Bool bool
+
+func (b Bool) MarshalJSON() ([]byte, error) {
+	if b {
+		return []byte("1"), nil
+	}
+	return []byte("0"), nil
+}
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "1", "true":
+		*b = true
+	case "0", "false", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean value: %s", data)
+	}
+	return nil
+}
+
+// flexibleID decodes an object ID that RMON returns either as a number or as
+// a numeric string, depending on the endpoint.
+type flexibleID int
+
+func (id *flexibleID) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "null" || raw == "" {
+		*id = 0
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid ID: %s", data)
+	}
+	*id = flexibleID(value)
+	return nil
+}
+
+// createResponse is the body RMON returns when an object is created.
+type createResponse struct {
+	ID     flexibleID `json:"id"`
+	Status string     `json:"status"`
+}
+
+func (c *Client) initServices() {
+	c.Groups = &GroupService{client: c}
+	c.Servers = &ServerService{client: c}
+	c.SSHCredentials = &SSHCredentialService{client: c}
+	c.Agents = &AgentService{client: c}
+	c.Regions = &RegionService{client: c}
+	c.Countries = &CountryService{client: c}
+	c.Channels = &ChannelService{client: c}
+	c.Users = &UserService{client: c}
+	c.Roles = &RoleService{client: c}
+	c.RoleBindings = &RoleBindingService{client: c}
+	c.CheckGroups = &CheckGroupService{client: c}
+	c.Checks = newCheckServices(c)
+}
@@ -0,0 +1,99 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// User is an RMON account. Password is write-only.
+type User struct {
+	Email    string `json:"email"`
+	Enabled  Bool   `json:"enabled"`
+	Password string `json:"password,omitempty"`
+	Username string `json:"username"`
+}
+
+type UserService struct {
+	client *Client
+}
+
+func (s *UserService) Create(ctx context.Context, user *User) (int, error) {
+	return s.client.create(ctx, apiPrefix+"/user", user)
+}
+
+func (s *UserService) Get(ctx context.Context, id int) (*User, error) {
+	var user User
+	if err := s.client.Do(ctx, "GET", userPath(id), nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *UserService) Update(ctx context.Context, id int, user *User) error {
+	return s.client.Do(ctx, "PUT", userPath(id), user, nil)
+}
+
+func (s *UserService) Delete(ctx context.Context, id int) error {
+	return s.client.Do(ctx, "DELETE", userPath(id), nil, nil)
+}
+
+func userPath(id int) string {
+	return fmt.Sprintf("%s/user/%d", apiPrefix, id)
+}
+
+type Role struct {
+	ID          int    `json:"role_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleService struct {
+	client *Client
+}
+
+func (s *RoleService) List(ctx context.Context) ([]Role, error) {
+	var roles []Role
+	if err := s.client.Do(ctx, "GET", apiPrefix+"/user/roles", nil, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// RoleBinding is the role a user has within a group.
+type RoleBinding struct {
+	GroupID int `json:"user_group_id"`
+	RoleID  int `json:"user_role_id"`
+}
+
+type RoleBindingService struct {
+	client *Client
+}
+
+// List returns every group the user belongs to along with the role in it.
+func (s *RoleBindingService) List(ctx context.Context, userID int) ([]RoleBinding, error) {
+	var bindings []RoleBinding
+	if err := s.client.Do(ctx, "GET", fmt.Sprintf("%s/groups", userPath(userID)), nil, &bindings); err != nil {
+		return nil, err
+	}
+	return bindings, nil
+}
+
+func (s *RoleBindingService) Create(ctx context.Context, userID, groupID, roleID int) error {
+	return s.client.Do(ctx, "POST", roleBindingPath(userID, groupID), roleBindingRequest{RoleID: roleID}, nil)
+}
+
+func (s *RoleBindingService) Update(ctx context.Context, userID, groupID, roleID int) error {
+	return s.client.Do(ctx, "PUT", roleBindingPath(userID, groupID), roleBindingRequest{RoleID: roleID}, nil)
+}
+
+func (s *RoleBindingService) Delete(ctx context.Context, userID, groupID int) error {
+	return s.client.Do(ctx, "DELETE", roleBindingPath(userID, groupID), nil, nil)
+}
+
+type roleBindingRequest struct {
+	RoleID int `json:"role_id"`
+}
+
+func roleBindingPath(userID, groupID int) string {
+	return fmt.Sprintf("%s/groups/%d", userPath(userID), groupID)
+}
@@ -0,0 +1,21 @@
+package rmonapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// ServerVersion asks RMON which version it runs.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	var result struct {
+		Version string `json:"version"`
+	}
+	if err := c.Do(ctx, "GET", apiPrefix+"/version", nil, &result); err != nil {
+		return "", err
+	}
+
+	if result.Version == "" {
+		return "", fmt.Errorf("unable to find version in response")
+	}
+	return result.Version, nil
+}
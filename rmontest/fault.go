@@ -0,0 +1,70 @@
+package rmontest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Fault makes the server answer matching requests with an error instead of
+// handling them.
+type Fault struct {
+	// Method matches the HTTP method. Empty matches any method.
+	Method string
+	// Path matches requests whose URL path starts with it, e.g.
+	// "/api/v1.0/server". Empty matches any path.
+	Path       string
+	StatusCode int
+	// Body is sent as the error message. Defaults to the status text.
+	Body string
+	// RetryAfter, in seconds, is sent as the Retry-After header when set.
+	RetryAfter int
+	// Times limits how many requests the fault applies to. Zero means
+	// until ClearFaults is called.
+	Times int
+
+	hits int
+}
+
+// InjectFault registers a fault. Faults are checked in the order they were
+// added, before authentication.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for _, fault := range s.faults {
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			continue
+		}
+		if fault.Method != "" && !strings.EqualFold(fault.Method, r.Method) {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.Path) {
+			continue
+		}
+		fault.hits++
+		return fault
+	}
+	return nil
+}
+
+func (f *Fault) write(w http.ResponseWriter) {
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(f.RetryAfter))
+	}
+
+	message := f.Body
+	if message == "" {
+		message = http.StatusText(f.StatusCode)
+	}
+	writeError(w, f.StatusCode, message)
+}
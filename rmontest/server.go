@@ -0,0 +1,594 @@
+// Package rmontest provides an in-memory fake of the RMON REST API for tests.
+// It implements login and the CRUD endpoints used by the Terraform provider,
+// keeps state in memory and supports fault injection.
+package rmontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	DefaultLogin    = "admin"
+	DefaultPassword = "admin"
+	// DefaultAPIToken is accepted as a static bearer token without login.
+	DefaultAPIToken = "rmontest-api-token"
+	DefaultVersion  = "1.2.0"
+	// DefaultAgentVersion is reported for agents without a status set by
+	// SetAgentStatus.
+	DefaultAgentVersion = "1.2.0"
+
+	// DefaultGroupID is the ID of the built-in Default group.
+	DefaultGroupID = 1
+
+	apiPrefix = "/api/v1.0/"
+)
+
+// Kinds of objects stored by the fake, named after their API path below
+// /api/v1.0. Channels are stored per receiver as "channel/<receiver>".
+const (
+	KindGroup         = "group"
+	KindServer        = "server"
+	KindSSHCredential = "server/cred"
+	KindAgent         = "rmon/agent"
+	KindRegion        = "rmon/region"
+	KindCountry       = "rmon/country"
+	KindCheckGroup    = "rmon/check-group"
+	KindUser          = "user"
+)
+
+// crudKinds are the fixed collection paths. Check and channel kinds are
+// derived from the request path.
+var crudKinds = []string{
+	KindGroup,
+	KindServer,
+	KindSSHCredential,
+	KindAgent,
+	KindRegion,
+	KindCountry,
+	KindCheckGroup,
+	KindUser,
+}
+
+// CheckTypes are the check types served under /rmon/check/{type}.
+var CheckTypes = []string{"http", "tcp", "ping", "dns", "smtp", "rabbitmq"}
+
+// writeOnlyFields are accepted on write but never returned by RMON.
+var writeOnlyFields = map[string][]string{
+	KindUser:  {"password"},
+	KindAgent: {"reconfigure"},
+}
+
+// Object is a stored RMON object as it would be returned by a GET.
+type Object map[string]interface{}
+
+// Request is a request received by the fake, recorded for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+}
+
+// Server is a fake RMON API. Create it with NewServer and Close it when done.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// version is returned by /version. An empty version answers 404, like
+	// RMON releases that predate the endpoint.
+	version string
+	tokens  map[string]bool
+	nextID  map[string]int
+	objects map[string]map[int]Object
+	// bindings maps user ID to group ID to role ID.
+	bindings map[int]map[int]int
+	// agentStatus overrides the status reported for an agent ID.
+	agentStatus map[int]Object
+	// checkStatus holds the status reported for a check, keyed by its kind
+	// and ID.
+	checkStatus map[string]map[int]Object
+	roles       []Object
+	faults      []*Fault
+	requests    []Request
+}
+
+func NewServer() *Server {
+	s := &Server{
+		version:     DefaultVersion,
+		tokens:      map[string]bool{},
+		nextID:      map[string]int{},
+		objects:     map[string]map[int]Object{},
+		bindings:    map[int]map[int]int{},
+		agentStatus: map[int]Object{},
+		checkStatus: map[string]map[int]Object{},
+		roles: []Object{
+			{"role_id": 1, "name": "superAdmin", "description": "Has the highest level of administrative permissions"},
+			{"role_id": 2, "name": "admin", "description": "Has access everywhere except the Admin area"},
+			{"role_id": 3, "name": "user", "description": "Has the same rights as the admin but has no access to the Servers page"},
+			{"role_id": 4, "name": "guest", "description": "Read-only access"},
+		},
+	}
+
+	s.Create(KindGroup, Object{"name": "Default", "description": "All servers are included in this group by default"})
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// SetVersion changes the version reported by /version.
+func (s *Server) SetVersion(version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version = version
+}
+
+// SetAgentStatus changes the status and version reported for an agent.
+// Agents are online and run DefaultAgentVersion until this is called.
+func (s *Server) SetAgentStatus(id int, status, version string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.agentStatus[id] = Object{"status": status, "version": version}
+}
+
+// SetCheckStatus sets the status reported for a check of the given type, e.g.
+// "http". Checks are reported as unknown until this is called.
+func (s *Server) SetCheckStatus(checkType string, id int, status Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kind := "rmon/check/" + checkType
+	if s.checkStatus[kind] == nil {
+		s.checkStatus[kind] = map[int]Object{}
+	}
+	s.checkStatus[kind][id] = copyObject(status)
+}
+
+// Create stores obj as if it had been created through the API and returns
+// its ID. Use it to seed objects that tests read through data sources.
+func (s *Server) Create(kind string, obj Object) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(kind, obj)
+}
+
+// Get returns a copy of a stored object.
+func (s *Server) Get(kind string, id int) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// Update merges fields into a stored object behind the provider's back, to
+// simulate out-of-band changes. It reports whether the object exists.
+func (s *Server) Update(kind string, id int, fields Object) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		return false
+	}
+	for key, value := range fields {
+		obj[key] = value
+	}
+	return true
+}
+
+// Delete removes an object behind the provider's back, to simulate
+// out-of-band deletion.
+func (s *Server) Delete(kind string, id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects[kind], id)
+}
+
+// Count returns the number of stored objects of the given kind.
+func (s *Server) Count(kind string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.objects[kind])
+}
+
+// ExpireTokens invalidates every token issued by login, so the next request
+// made with one of them is answered with 401. The static API token stays
+// valid.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]bool{}
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) create(kind string, obj Object) int {
+	if s.objects[kind] == nil {
+		s.objects[kind] = map[int]Object{}
+	}
+	s.nextID[kind]++
+	id := s.nextID[kind]
+	s.objects[kind][id] = copyObject(obj)
+	return id
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone()})
+
+	if fault := s.matchFault(r); fault != nil {
+		fault.write(w)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, apiPrefix), "/")
+
+	if path == "login" && r.Method == http.MethodPost {
+		s.handleLogin(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Token has expired")
+		return
+	}
+
+	var body Object
+	if r.Method == http.MethodPost || r.Method == http.MethodPut || r.Method == http.MethodPatch {
+		if err := decodeBody(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	segments := strings.Split(path, "/")
+	switch {
+	case path == "version" && r.Method == http.MethodGet:
+		s.handleVersion(w)
+	case path == "groups" && r.Method == http.MethodGet:
+		s.handleGroupList(w)
+	case path == "user/roles" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.roles)
+	case len(segments) >= 3 && segments[0] == "user" && segments[2] == "groups":
+		s.handleBinding(w, r, segments, body)
+	case len(segments) == 4 && segments[0] == "rmon" && segments[1] == "agent" && segments[3] == "status" && r.Method == http.MethodGet:
+		s.handleAgentStatus(w, segments[2])
+	case segments[0] == "channel" && len(segments) == 4 && segments[3] == "test" && r.Method == http.MethodPost:
+		s.handleChannelTest(w, r, "channel/"+segments[1], segments[2])
+	case segments[0] == "channel" && len(segments) >= 2:
+		s.handleCRUD(w, r, "channel/"+segments[1], segments[2:], body)
+	case len(segments) == 5 && segments[0] == "rmon" && segments[1] == "check" && isCheckType(segments[2]) && segments[4] == "status" && r.Method == http.MethodGet:
+		s.handleCheckStatus(w, strings.Join(segments[:3], "/"), segments[3])
+	case len(segments) >= 3 && segments[0] == "rmon" && segments[1] == "check" && isCheckType(segments[2]):
+		s.handleCRUD(w, r, strings.Join(segments[:3], "/"), segments[3:], body)
+	default:
+		for _, kind := range crudKinds {
+			kindSegments := strings.Split(kind, "/")
+			if len(segments) < len(kindSegments) || strings.Join(segments[:len(kindSegments)], "/") != kind {
+				continue
+			}
+			rest := segments[len(kindSegments):]
+			// "server/cred" must not be taken for server ID "cred".
+			if len(rest) > 0 {
+				if _, err := strconv.Atoi(rest[0]); err != nil {
+					continue
+				}
+			}
+			s.handleCRUD(w, r, kind, rest, body)
+			return
+		}
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var creds struct {
+		Login    string `json:"login"`
+		Password string `json:"password"`
+	}
+	if err := decodeBody(r, &creds); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if creds.Login != DefaultLogin || creds.Password != DefaultPassword {
+		writeError(w, http.StatusUnauthorized, "Invalid login or password")
+		return
+	}
+
+	token := fmt.Sprintf("rmontest-token-%d", len(s.requests))
+	s.tokens[token] = true
+	writeJSON(w, http.StatusOK, Object{"access_token": token})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token == DefaultAPIToken || s.tokens[token]
+}
+
+func (s *Server) handleVersion(w http.ResponseWriter) {
+	if s.version == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, Object{"version": s.version})
+}
+
+func (s *Server) handleGroupList(w http.ResponseWriter) {
+	groups := make([]Object, 0, len(s.objects[KindGroup]))
+	for _, id := range sortedIDs(s.objects[KindGroup]) {
+		group := copyObject(s.objects[KindGroup][id])
+		group["group_id"] = id
+		groups = append(groups, group)
+	}
+	writeJSON(w, http.StatusOK, groups)
+}
+
+// handleList returns every object of a kind, with its ID in "id".
+func (s *Server) handleList(w http.ResponseWriter, kind string) {
+	objects := make([]Object, 0, len(s.objects[kind]))
+	for _, id := range sortedIDs(s.objects[kind]) {
+		obj := copyObject(s.objects[kind][id])
+		for _, field := range writeOnlyFields[kind] {
+			delete(obj, field)
+		}
+		obj["id"] = id
+		objects = append(objects, obj)
+	}
+	writeJSON(w, http.StatusOK, objects)
+}
+
+// handleAgentStatus serves GET rmon/agent/<id>/status.
+func (s *Server) handleAgentStatus(w http.ResponseWriter, rawID string) {
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "agent not found")
+		return
+	}
+	if _, ok := s.objects[KindAgent][id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", KindAgent, id))
+		return
+	}
+	if status, ok := s.agentStatus[id]; ok {
+		writeJSON(w, http.StatusOK, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, Object{"status": "online", "version": DefaultAgentVersion})
+}
+
+// handleCheckStatus serves GET rmon/check/<type>/<id>/status.
+func (s *Server) handleCheckStatus(w http.ResponseWriter, kind, rawID string) {
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "check not found")
+		return
+	}
+	if _, ok := s.objects[kind][id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", kind, id))
+		return
+	}
+	if status, ok := s.checkStatus[kind][id]; ok {
+		writeJSON(w, http.StatusOK, status)
+		return
+	}
+	writeJSON(w, http.StatusOK, Object{"status": "unknown", "agents": []Object{}})
+}
+
+// handleChannelTest pretends to deliver a test message. Use InjectFault to
+// make delivery fail.
+func (s *Server) handleChannelTest(w http.ResponseWriter, r *http.Request, kind, rawID string) {
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+		return
+	}
+	if _, ok := s.objects[kind][id]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", kind, id))
+		return
+	}
+	writeJSON(w, http.StatusOK, Object{"status": "Ok"})
+}
+
+// handleCRUD serves GET/POST <kind> and GET/PUT/PATCH/DELETE <kind>/<id>.
+func (s *Server) handleCRUD(w http.ResponseWriter, r *http.Request, kind string, rest []string, body Object) {
+	if len(rest) == 0 {
+		if r.Method == http.MethodGet {
+			s.handleList(w, kind)
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		id := s.create(kind, body)
+		if kind == KindSSHCredential {
+			writeJSON(w, http.StatusCreated, Object{"id": id, "status": "Ok"})
+			return
+		}
+		writeJSON(w, http.StatusCreated, Object{"id": id})
+		return
+	}
+
+	id, err := strconv.Atoi(rest[0])
+	if err != nil || len(rest) > 1 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+		return
+	}
+
+	obj, ok := s.objects[kind][id]
+	if !ok {
+		// RMON answers an unknown SSH credential ID with an empty list.
+		if kind == KindSSHCredential && r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, []Object{})
+			return
+		}
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %d not found", kind, id))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		result := copyObject(obj)
+		for _, field := range writeOnlyFields[kind] {
+			delete(result, field)
+		}
+		if kind == KindSSHCredential {
+			writeJSON(w, http.StatusOK, []Object{result})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	case http.MethodPut, http.MethodPatch:
+		for key, value := range body {
+			obj[key] = value
+		}
+		writeJSON(w, http.StatusOK, Object{"status": "Ok"})
+	case http.MethodDelete:
+		delete(s.objects[kind], id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// handleBinding serves user/<user_id>/groups[/<group_id>].
+func (s *Server) handleBinding(w http.ResponseWriter, r *http.Request, segments []string, body Object) {
+	userID, err := strconv.Atoi(segments[1])
+	if err != nil {
+		writeError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if _, ok := s.objects[KindUser][userID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("user %d not found", userID))
+		return
+	}
+
+	if len(segments) == 3 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		bindings := make([]Object, 0, len(s.bindings[userID]))
+		for _, groupID := range sortedIDs(s.bindings[userID]) {
+			bindings = append(bindings, Object{
+				"user_group_id": groupID,
+				"user_role_id":  s.bindings[userID][groupID],
+			})
+		}
+		writeJSON(w, http.StatusOK, bindings)
+		return
+	}
+
+	groupID, err := strconv.Atoi(segments[3])
+	if err != nil || len(segments) > 4 {
+		writeError(w, http.StatusNotFound, "group not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		roleID, ok := body["role_id"].(float64)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "role_id is required")
+			return
+		}
+		if s.bindings[userID] == nil {
+			s.bindings[userID] = map[int]int{}
+		}
+		s.bindings[userID][groupID] = int(roleID)
+		writeJSON(w, http.StatusOK, Object{"status": "Ok"})
+	case http.MethodDelete:
+		if _, ok := s.bindings[userID][groupID]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("user %d is not in group %d", userID, groupID))
+			return
+		}
+		delete(s.bindings[userID], groupID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// DeleteBinding removes a user from a group behind the provider's back.
+func (s *Server) DeleteBinding(userID, groupID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bindings[userID], groupID)
+}
+
+// Binding returns the role the user has in the group.
+func (s *Server) Binding(userID, groupID int) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	roleID, ok := s.bindings[userID][groupID]
+	return roleID, ok
+}
+
+func isCheckType(checkType string) bool {
+	for _, t := range CheckTypes {
+		if t == checkType {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, Object{"status": "failed", "error": message})
+}
+
+func copyObject(obj Object) Object {
+	result := make(Object, len(obj))
+	for key, value := range obj {
+		result[key] = value
+	}
+	return result
+}
+
+func sortedIDs[V any](m map[int]V) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}